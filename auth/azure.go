@@ -0,0 +1,154 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/microsoft"
+)
+
+// azureProvider logs users in with Azure AD and reads their profile from Microsoft Graph
+type azureProvider struct {
+	config *oauth2.Config
+}
+
+func newAzureProvider() *azureProvider {
+	return &azureProvider{
+		config: &oauth2.Config{
+			ClientID:     os.Getenv("AZURE_CLIENT_ID"),
+			ClientSecret: os.Getenv("AZURE_CLIENT_SECRET"),
+			RedirectURL:  os.Getenv("AZURE_REDIRECT_URL"),
+			Scopes:       []string{"https://graph.microsoft.com/.default"},
+			Endpoint:     microsoft.AzureADEndpoint(os.Getenv("AZURE_TENANT_ID")),
+		},
+	}
+}
+
+func (p *azureProvider) AuthCodeURL(state string, verifier string, loginHint string) string {
+	opts := []oauth2.AuthCodeOption{oauth2.S256ChallengeOption(verifier)}
+	if loginHint != "" {
+		opts = append(opts, oauth2.SetAuthURLParam("login_hint", loginHint))
+	}
+	return p.config.AuthCodeURL(state, opts...)
+}
+
+func (p *azureProvider) Exchange(ctx context.Context, code string, state string, verifier string) (*Identity, error) {
+	token, err := p.config.Exchange(ctx, code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return nil, fmt.Errorf("code exchange failed: %w", err)
+	}
+
+	user, err := p.getUserInfo(ctx, token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user info: %w", err)
+	}
+
+	// Accounts without a mailbox have no mail, their principal name is an email address too
+	email := user.Mail
+	if email == "" {
+		email = user.UserPrincipalName
+	}
+
+	identity := &Identity{
+		Subject: user.ID,
+		Name:    user.DisplayName,
+		Email:   email,
+		// The mail of a user is only as trustworthy as the tenant that set it, so it is only
+		// vouched for when logins are restricted to our own tenant
+		EmailVerified: singleTenant(os.Getenv("AZURE_TENANT_ID")),
+	}
+
+	// Reading memberships needs the GroupMember.Read.All permission, so only ask when groups are mapped
+	if groups["azure"].enabled() {
+		identity.Groups, err = p.getGroups(ctx, token)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get groups: %w", err)
+		}
+	}
+
+	return identity, nil
+}
+
+// singleTenant reports whether the tenant names one directory, rather than one of the endpoints
+// that accept accounts from any tenant
+func singleTenant(tenant string) bool {
+	switch strings.ToLower(tenant) {
+	case "", "common", "organizations", "consumers":
+		return false
+	}
+	return true
+}
+
+// User struct to hold the user information from Microsoft Graph
+type User struct {
+	ID                string `json:"id"`
+	DisplayName       string `json:"displayName"`
+	Mail              string `json:"mail"`
+	UserPrincipalName string `json:"userPrincipalName"` // Often used as the user's email
+}
+
+func (p *azureProvider) getUserInfo(ctx context.Context, token *oauth2.Token) (*User, error) {
+	client := p.config.Client(ctx, token)
+	resp, err := client.Get("https://graph.microsoft.com/v1.0/me")
+	if err != nil {
+		return nil, fmt.Errorf("request to Graph API failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Graph API returned non-200 status: %d", resp.StatusCode)
+	}
+
+	var user User
+	if err := json.NewDecoder(resp.Body).Decode(&user); err != nil {
+		return nil, fmt.Errorf("decoding response body failed: %v", err)
+	}
+
+	return &user, nil
+}
+
+// group is a security group or Microsoft 365 group from Microsoft Graph
+type group struct {
+	ID string `json:"id"`
+}
+
+// getGroups returns the object ids of the groups the user is a member of, directly or through
+// nested groups
+func (p *azureProvider) getGroups(ctx context.Context, token *oauth2.Token) ([]string, error) {
+	client := p.config.Client(ctx, token)
+
+	var memberOf []string
+	next := "https://graph.microsoft.com/v1.0/me/transitiveMemberOf/microsoft.graph.group?$select=id"
+	for next != "" {
+		resp, err := client.Get(next)
+		if err != nil {
+			return nil, fmt.Errorf("request to Graph API failed: %v", err)
+		}
+
+		var page struct {
+			Value    []group `json:"value"`
+			NextLink string  `json:"@odata.nextLink"`
+		}
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, fmt.Errorf("Graph API returned non-200 status: %d", resp.StatusCode)
+		}
+		err = json.NewDecoder(resp.Body).Decode(&page)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("decoding response body failed: %v", err)
+		}
+
+		for _, g := range page.Value {
+			memberOf = append(memberOf, g.ID)
+		}
+		next = page.NextLink
+	}
+
+	return memberOf, nil
+}
@@ -0,0 +1,128 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"graphql-go/persistence"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SessionCookieName is the cookie holding the session secret in cookie session mode
+const SessionCookieName = "burger_session"
+
+var ErrCrossOrigin = errors.New("Cross-origin request rejected")
+
+// IssueCookieSession starts a session for the user that is authenticated by a cookie instead of
+// bearer tokens, and returns the secret to store in the cookie
+func IssueCookieSession(db *gorm.DB, user *persistence.User) (string, error) {
+	secret, err := generateRefreshToken()
+	if err != nil {
+		return "", err
+	}
+
+	err = db.Create(&persistence.Session{
+		ID:               uuid.New().String(),
+		UserId:           user.ID,
+		RefreshTokenHash: hashToken(secret),
+		Cookie:           true,
+		ExpiresAt:        time.Now().Add(refreshTokenTTL()),
+	}).Error
+	if err != nil {
+		return "", err
+	}
+
+	return secret, nil
+}
+
+func setSessionCookie(w http.ResponseWriter, secret string) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     SessionCookieName,
+		Value:    secret,
+		MaxAge:   int(refreshTokenTTL().Seconds()),
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+		Path:     "/",
+	})
+}
+
+func clearSessionCookie(w http.ResponseWriter) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     SessionCookieName,
+		Value:    "",
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+		Path:     "/",
+	})
+}
+
+// cookieSession loads the active cookie session with the given secret
+func cookieSession(db *gorm.DB, secret string) (*persistence.Session, error) {
+	session := &persistence.Session{}
+	res := db.Where("refresh_token_hash = ? AND cookie = ?", hashToken(secret), true).Limit(1).Find(session)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	if res.RowsAffected == 0 || session.RevokedAt != nil || time.Now().After(session.ExpiresAt) {
+		return nil, ErrInvalidToken
+	}
+	return session, nil
+}
+
+// authenticateSessionCookie returns a context carrying the user of the session cookie. Since
+// browsers attach cookies to cross-site requests too, requests that can change state must
+// come from our own origin or an allowed frontend.
+func authenticateSessionCookie(ctx context.Context, db *gorm.DB, r *http.Request, secret string) (context.Context, error) {
+	if !safeRequest(r) && !trustedOrigin(r) {
+		return nil, ErrCrossOrigin
+	}
+
+	db = db.WithContext(ctx)
+	session, err := cookieSession(db, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := activeUser(db, session.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	return WithUser(ctx, user), nil
+}
+
+// safeRequest reports whether the request cannot change state. WebSocket upgrades are GET
+// requests, but the socket can carry mutations, so they are not safe.
+func safeRequest(r *http.Request) bool {
+	if r.Header.Get("Upgrade") == "websocket" {
+		return false
+	}
+	return r.Method == http.MethodGet || r.Method == http.MethodHead || r.Method == http.MethodOptions
+}
+
+// trustedOrigin reports whether the request was sent by a page on this server, e.g. the
+// playground, or on one of the ALLOWED_REDIRECT_ORIGINS frontends
+func trustedOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		// Some browsers omit Origin on same-origin requests, fall back to the Referer
+		referer, err := url.Parse(r.Header.Get("Referer"))
+		if err != nil || referer.Host == "" {
+			return false
+		}
+		origin = referer.Scheme + "://" + referer.Host
+	}
+
+	parsed, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	return parsed.Host == r.Host || AllowedOrigin(origin)
+}
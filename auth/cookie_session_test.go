@@ -0,0 +1,92 @@
+package auth
+
+import (
+	"errors"
+	"graphql-go/graph/model"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// Browsers send the session cookie along with requests from any site, so only safe requests
+// and requests from our own pages may use it
+func TestSessionCookieOrigin(t *testing.T) {
+	db := newTestDB(t)
+	t.Setenv("ALLOWED_REDIRECT_ORIGINS", "https://burger.example.com")
+	user := addTestUser(t, db, "member", model.RoleMember)
+	secret, err := IssueCookieSession(db, user)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	handler := Middleware(db)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := ForContext(r.Context()); got == nil || got.ID != user.ID {
+			t.Errorf("got user %v, want the user of the session", got)
+		}
+	}))
+
+	tests := []struct {
+		name    string
+		method  string
+		headers map[string]string
+		status  int
+	}{
+		{"same origin", http.MethodPost, map[string]string{"Origin": "http://example.com"}, http.StatusOK},
+		{"allowed frontend", http.MethodPost, map[string]string{"Origin": "https://burger.example.com"}, http.StatusOK},
+		{"same origin referer", http.MethodPost, map[string]string{"Referer": "http://example.com/playground"}, http.StatusOK},
+		{"safe request", http.MethodGet, map[string]string{"Origin": "https://evil.example.com"}, http.StatusOK},
+		{"other origin", http.MethodPost, map[string]string{"Origin": "https://evil.example.com"}, http.StatusForbidden},
+		{"opaque origin", http.MethodPost, map[string]string{"Origin": "null"}, http.StatusForbidden},
+		{"other referer", http.MethodPost, map[string]string{"Referer": "https://evil.example.com/example.com"}, http.StatusForbidden},
+		{"origin wins over referer", http.MethodPost, map[string]string{"Origin": "https://evil.example.com", "Referer": "http://example.com/"}, http.StatusForbidden},
+		{"neither", http.MethodPost, nil, http.StatusForbidden},
+		{"websocket upgrade", http.MethodGet, map[string]string{"Origin": "https://evil.example.com", "Upgrade": "websocket"}, http.StatusForbidden},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := httptest.NewRequest(test.method, "http://example.com/query", nil)
+			r.AddCookie(&http.Cookie{Name: SessionCookieName, Value: secret})
+			for key, value := range test.headers {
+				r.Header.Set(key, value)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+			if w.Code != test.status {
+				t.Errorf("got status %d, want %d", w.Code, test.status)
+			}
+		})
+	}
+}
+
+func TestSessionCookieLogout(t *testing.T) {
+	db := newTestDB(t)
+	user := addTestUser(t, db, "member", model.RoleMember)
+	secret, err := IssueCookieSession(db, user)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	logout := func(origin string) int {
+		r := httptest.NewRequest(http.MethodPost, "http://example.com/logout", nil)
+		r.AddCookie(&http.Cookie{Name: SessionCookieName, Value: secret})
+		r.Header.Set("Origin", origin)
+		w := httptest.NewRecorder()
+		HandleLogout(db)(w, r)
+		return w.Code
+	}
+
+	// Another site cannot log the user out either
+	if code := logout("https://evil.example.com"); code != http.StatusForbidden {
+		t.Errorf("logging out from another origin: got status %d, want 403", code)
+	}
+	if _, err := cookieSession(db, secret); err != nil {
+		t.Errorf("session after logging out from another origin: %v", err)
+	}
+
+	if code := logout("http://example.com"); code != http.StatusNoContent {
+		t.Errorf("logging out: got status %d, want 204", code)
+	}
+	if _, err := cookieSession(db, secret); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("session after logging out: got %v, want ErrInvalidToken", err)
+	}
+}
@@ -2,12 +2,12 @@ package auth
 
 import (
 	"context"
-	"fmt"
+	"errors"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
 	"github.com/golang-jwt/jwt/v5"
 	"gorm.io/gorm"
 	"graphql-go/persistence"
 	"net/http"
-	"os"
 	"strings"
 )
 
@@ -19,71 +19,159 @@ type contextKey struct {
 	name string
 }
 
+var (
+	ErrMissingAuthorization = errors.New("Missing Authorization header")
+	ErrInvalidAuthorization = errors.New("Invalid Authorization header format")
+	ErrInvalidToken         = errors.New("Invalid token")
+	ErrDeactivated          = errors.New("Account deactivated")
+)
+
 // Middleware decodes the share session cookie and packs the session into context
 func Middleware(db *gorm.DB) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Requests without an Authorization header may use a session cookie instead
+			if cookie, err := r.Cookie(SessionCookieName); err == nil && r.Header.Get("Authorization") == "" {
+				ctx, err := authenticateSessionCookie(r.Context(), db, r, cookie.Value)
+				if errors.Is(err, ErrCrossOrigin) {
+					http.Error(w, err.Error(), http.StatusForbidden)
+					return
+				}
+				if err != nil {
+					http.Error(w, err.Error(), http.StatusUnauthorized)
+					return
+				}
+
+				next.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+
 			// Special handling for WebSocket connections
 			// Check if this is a WebSocket upgrade request
 			if websocket := r.Header.Get("Upgrade"); websocket == "websocket" {
-				// Allow WebSocket upgrade requests to proceed without authentication
-				// The subscription resolvers can implement their own auth if needed
+				// Browsers cannot set headers on WebSocket upgrades, so the token is sent in
+				// the connection_init payload instead and validated by WebsocketInitFunc
 				next.ServeHTTP(w, r)
 				return
 			}
 
-			// Check for JWT in Authorization header
-			authHeader := r.Header.Get("Authorization")
-
-			if authHeader == "" {
-				http.Error(w, "Missing Authorization header", http.StatusUnauthorized)
+			ctx, err := Authenticate(r.Context(), db, r.Header.Get("Authorization"))
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusUnauthorized)
 				return
 			}
 
-			// The header should be in the format `Bearer <token>`
-			parts := strings.Split(authHeader, " ")
-			if len(parts) != 2 || parts[0] != "Bearer" {
-				http.Error(w, "Invalid Authorization header format", http.StatusUnauthorized)
-				return
-			}
+			// call the next with our new context
+			r = r.WithContext(ctx)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
 
-			// Validate the JWT
-			tokenString := parts[1]
-			token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-				// Make sure the token method is what we expect
-				if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-					return nil, fmt.Errorf("Unexpected signing method: %v", token.Header["alg"])
-				}
+// WebsocketInitFunc authenticates WebSocket connections from the Authorization value in the
+// connection_init payload and rejects the socket if it is missing or invalid. Sockets opened
+// with a session cookie were already authenticated by Middleware during the upgrade.
+func WebsocketInitFunc(db *gorm.DB) transport.WebsocketInitFunc {
+	return func(ctx context.Context, initPayload transport.InitPayload) (context.Context, *transport.InitPayload, error) {
+		if initPayload.Authorization() == "" && ForContext(ctx) != nil {
+			return ctx, nil, nil
+		}
 
-				// Return the secret key (this should be more secure in production)
-				// Replace "your_secret_key" with your actual secret key
-				return []byte(os.Getenv("JWT_SECRET")), nil
-			})
-			if err != nil {
-				http.Error(w, "Invalid token", http.StatusUnauthorized)
-				return
-			}
+		authCtx, err := Authenticate(ctx, db, initPayload.Authorization())
+		if err != nil {
+			return ctx, nil, err
+		}
 
-			if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-				// You can access the token claims here if needed
-				// get the user from the database
-				sub, _ := claims.GetSubject()
-				user := &persistence.User{ID: sub}
-				db.First(user)
+		return authCtx, nil, nil
+	}
+}
 
-				// put it in context
-				ctx := context.WithValue(r.Context(), userCtxKey, user)
+// Authenticate validates an authorization value in the format `Bearer <token>`, where the token
+// is either an access token or an API token, and returns a context carrying the user it was
+// issued for. Requests made with API tokens are limited to the token's scopes.
+func Authenticate(ctx context.Context, db *gorm.DB, authHeader string) (context.Context, error) {
+	db = db.WithContext(ctx)
+	if token, ok := strings.CutPrefix(authHeader, "Bearer "+apiTokenPrefix); ok {
+		user, scopes, err := authenticateAPIToken(db, apiTokenPrefix+token)
+		if err != nil {
+			return nil, err
+		}
+		return WithScopes(WithUser(ctx, user), scopes), nil
+	}
 
-				// and call the next with our new context
-				r = r.WithContext(ctx)
-				next.ServeHTTP(w, r)
-			} else {
-				http.Error(w, "Invalid token", http.StatusUnauthorized)
-				return
-			}
+	claims, err := parseAuthorization(authHeader)
+	if err != nil {
+		return nil, err
+	}
 
-		})
+	revoked, err := isRevoked(db, claims)
+	if err != nil || revoked {
+		return nil, ErrInvalidToken
+	}
+
+	// get the user from the database
+	user, err := activeUser(db, claims.Subject)
+	if err != nil {
+		return nil, err
+	}
+
+	if claims.Actor != nil {
+		actor, err := authenticateActor(db, claims.Actor)
+		if err != nil {
+			return nil, err
+		}
+		ctx = WithActor(ctx, actor)
+	}
+
+	return WithUser(ctx, user), nil
+}
+
+// activeUser loads the user a credential was issued for. Deactivated users keep their orders,
+// but none of their credentials are accepted anymore.
+func activeUser(db *gorm.DB, userID string) (*persistence.User, error) {
+	user := &persistence.User{ID: userID}
+	if err := db.First(user).Error; err != nil {
+		return nil, ErrInvalidToken
+	}
+	if user.DeactivatedAt != nil {
+		return nil, ErrDeactivated
 	}
+	return user, nil
+}
+
+// parseAuthorization validates the access token in an authorization value and returns its claims
+func parseAuthorization(authHeader string) (*AccessClaims, error) {
+	if authHeader == "" {
+		return nil, ErrMissingAuthorization
+	}
+
+	// The header should be in the format `Bearer <token>`
+	parts := strings.Split(authHeader, " ")
+	if len(parts) != 2 || parts[0] != "Bearer" {
+		return nil, ErrInvalidAuthorization
+	}
+
+	// Validate the JWT
+	claims := &AccessClaims{}
+	token, err := jwt.ParseWithClaims(parts[1], claims, keys.keyFunc,
+		jwt.WithValidMethods([]string{"RS256", "ES256"}),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil || !token.Valid {
+		return nil, ErrInvalidToken
+	}
+
+	// Tokens issued before sessions existed cannot be revoked, so they are no longer accepted
+	if claims.ID == "" || claims.SessionID == "" {
+		return nil, ErrInvalidToken
+	}
+
+	return claims, nil
+}
+
+// WithUser returns a copy of the context carrying the user, retrievable with ForContext
+func WithUser(ctx context.Context, user *persistence.User) context.Context {
+	return context.WithValue(ctx, userCtxKey, user)
 }
 
 // ForContext finds the user from the context. REQUIRES Middleware to have run.
@@ -0,0 +1,64 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"graphql-go/graph/model"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+)
+
+func TestWebsocketInitFunc(t *testing.T) {
+	db := newTestDB(t)
+	user := addTestUser(t, db, "member", model.RoleMember)
+	pair, err := IssueTokens(db, user)
+	if err != nil {
+		t.Fatal(err)
+	}
+	revoked, err := IssueTokens(db, user)
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims, err := parseAuthorization("Bearer " + revoked.AccessToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := RevokeSession(db, claims.SessionID); err != nil {
+		t.Fatal(err)
+	}
+
+	initFunc := WebsocketInitFunc(db)
+	tests := []struct {
+		name    string
+		payload transport.InitPayload
+		err     error
+	}{
+		{"missing", transport.InitPayload{}, ErrMissingAuthorization},
+		{"not a bearer token", transport.InitPayload{"Authorization": pair.AccessToken}, ErrInvalidAuthorization},
+		{"invalid", transport.InitPayload{"Authorization": "Bearer junk"}, ErrInvalidToken},
+		{"revoked", transport.InitPayload{"Authorization": "Bearer " + revoked.AccessToken}, ErrInvalidToken},
+	}
+	for _, test := range tests {
+		if _, _, err := initFunc(context.Background(), test.payload); !errors.Is(err, test.err) {
+			t.Errorf("%s: got %v, want %v", test.name, err, test.err)
+		}
+	}
+
+	for _, key := range []string{"Authorization", "authorization"} {
+		ctx, _, err := initFunc(context.Background(), transport.InitPayload{key: "Bearer " + pair.AccessToken})
+		if err != nil {
+			t.Errorf("%s: %v", key, err)
+			continue
+		}
+		if got := ForContext(ctx); got == nil || got.ID != user.ID {
+			t.Errorf("%s: got user %v, want the user of the token", key, got)
+		}
+	}
+
+	// Sockets opened with a session cookie were authenticated during the upgrade
+	ctx, _, err := initFunc(WithUser(context.Background(), user), transport.InitPayload{})
+	if err != nil || ForContext(ctx) == nil {
+		t.Errorf("a socket authenticated by its cookie: got user %v: %v", ForContext(ctx), err)
+	}
+}
@@ -0,0 +1,139 @@
+package auth
+
+import (
+	"fmt"
+	"graphql-go/graph/model"
+	"graphql-go/persistence"
+	"os"
+	"slices"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// groupMapping maps directory groups, by id, to roles and office teams. Display names are not
+// mapped, anyone allowed to create a group could pick a name that grants a role.
+type groupMapping struct {
+	roles map[string]model.Role
+	teams map[string]string
+}
+
+// groups holds the mapping of each identity provider, keyed by provider name. It is configured at
+// startup by ConfigureProviders. A group id is only meaningful in the directory that issued it,
+// another provider could report the same id for a group anyone may join.
+var groups = map[string]groupMapping{}
+
+// enabled reports whether any groups are mapped, so providers only look up groups when needed
+func (m groupMapping) enabled() bool {
+	return m.roles != nil || m.teams != nil
+}
+
+// configureGroupMapping reads the comma separated group=value pairs of each provider from
+// GROUP_ROLES_<PROVIDER>, e.g. GROUP_ROLES_AZURE="<admins group id>=ADMIN,<hosts group id>=HOST",
+// and GROUP_TEAMS_<PROVIDER>, e.g. GROUP_TEAMS_AZURE="<group id>=Copenhagen"
+func configureGroupMapping(providerNames []string) error {
+	for _, key := range []string{"GROUP_ROLES", "GROUP_TEAMS"} {
+		if os.Getenv(key) != "" {
+			return fmt.Errorf("%s applies to no provider, set %s_<PROVIDER>, e.g. %s_AZURE", key, key, key)
+		}
+	}
+
+	configured := map[string]groupMapping{}
+	for _, name := range providerNames {
+		suffix := "_" + strings.ToUpper(name)
+		roles, err := parseGroupPairs("GROUP_ROLES" + suffix)
+		if err != nil {
+			return err
+		}
+		teams, err := parseGroupPairs("GROUP_TEAMS" + suffix)
+		if err != nil {
+			return err
+		}
+
+		mapping := groupMapping{teams: teams}
+		if roles != nil {
+			mapping.roles = map[string]model.Role{}
+			for group, role := range roles {
+				if !model.Role(role).IsValid() {
+					return fmt.Errorf("GROUP_ROLES%s: invalid role %s for group %s", suffix, role, group)
+				}
+				mapping.roles[group] = model.Role(role)
+			}
+		}
+		if mapping.enabled() {
+			configured[name] = mapping
+		}
+	}
+
+	groups = configured
+	return nil
+}
+
+func parseGroupPairs(key string) (map[string]string, error) {
+	var pairs map[string]string
+	for _, pair := range strings.Split(os.Getenv(key), ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		group, value, ok := strings.Cut(pair, "=")
+		group, value = strings.TrimSpace(group), strings.TrimSpace(value)
+		if !ok || group == "" || value == "" {
+			return nil, fmt.Errorf("%s: expected group=value, got %q", key, pair)
+		}
+		if pairs == nil {
+			pairs = map[string]string{}
+		}
+		pairs[group] = value
+	}
+	return pairs, nil
+}
+
+// syncGroups updates the role and teams of the user from the groups the provider reported at
+// login. The directory is authoritative for whatever is configured for the provider: with its
+// GROUP_ROLES_<PROVIDER> set, users without a mapped group become members, even when an admin
+// granted them a role, and with its GROUP_TEAMS_<PROVIDER> set, teams are replaced on every login.
+func syncGroups(db *gorm.DB, providerName string, user *persistence.User, memberOf []string) error {
+	mapping := groups[providerName]
+	updates := map[string]interface{}{}
+
+	if mapping.roles != nil {
+		role := model.RoleMember
+		for _, group := range memberOf {
+			if mapped, ok := mapping.roles[group]; ok && roleRank[mapped] > roleRank[role] {
+				role = mapped
+			}
+		}
+		if role != user.Role {
+			updates["role"] = role
+		}
+	}
+
+	if mapping.teams != nil {
+		teams := persistence.StringArray{}
+		for _, group := range memberOf {
+			if team, ok := mapping.teams[group]; ok && !slices.Contains(teams, team) {
+				teams = append(teams, team)
+			}
+		}
+		slices.Sort(teams)
+		if !slices.Equal(teams, user.Teams) {
+			updates["teams"] = teams
+		}
+	}
+
+	if len(updates) == 0 {
+		return nil
+	}
+	updates["version"] = gorm.Expr("version + 1")
+	if err := db.Model(user).Updates(updates).Error; err != nil {
+		return err
+	}
+
+	if role, ok := updates["role"].(model.Role); ok {
+		user.Role = role
+	}
+	if teams, ok := updates["teams"].(persistence.StringArray); ok {
+		user.Teams = teams
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package auth
+
+import (
+	"graphql-go/graph/model"
+	"graphql-go/persistence"
+	"slices"
+	"testing"
+)
+
+const (
+	adminsGroup     = "3f2a7c1e-admins"
+	hostsGroup      = "8b1d04aa-hosts"
+	copenhagenGroup = "c0ffee00-copenhagen"
+)
+
+func configureTestGroups(t *testing.T) {
+	t.Helper()
+
+	previous := groups
+	t.Cleanup(func() { groups = previous })
+
+	t.Setenv("GROUP_ROLES_AZURE", adminsGroup+"=ADMIN, "+hostsGroup+"=HOST")
+	t.Setenv("GROUP_TEAMS_AZURE", copenhagenGroup+"=Copenhagen")
+	if err := configureGroupMapping([]string{"azure", "google"}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSyncGroups(t *testing.T) {
+	db := newTestDB(t)
+	configureTestGroups(t)
+
+	user := &persistence.User{ID: "user", Name: "User", Email: "user@example.com", Role: model.RoleMember}
+	if err := db.Create(user).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if err := syncGroups(db, "azure", user, []string{hostsGroup, adminsGroup, copenhagenGroup, "unmapped"}); err != nil {
+		t.Fatal(err)
+	}
+	var stored persistence.User
+	if err := db.First(&stored, "id = ?", user.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if stored.Role != model.RoleAdmin || !slices.Equal(stored.Teams, persistence.StringArray{"Copenhagen"}) {
+		t.Errorf("got role %s and teams %v, want ADMIN in Copenhagen", stored.Role, stored.Teams)
+	}
+	if stored.Version != 2 || user.Role != stored.Role {
+		t.Errorf("got version %d and role %s in memory, want the update applied to both", stored.Version, user.Role)
+	}
+
+	// The directory wins over a role granted by hand once the groups are gone
+	if err := db.Model(user).Update("role", model.RoleHost).Error; err != nil {
+		t.Fatal(err)
+	}
+	user.Role = model.RoleHost
+	if err := syncGroups(db, "azure", user, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.First(&stored, "id = ?", user.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if stored.Role != model.RoleMember || len(stored.Teams) != 0 {
+		t.Errorf("got role %s and teams %v without groups, want a member without teams", stored.Role, stored.Teams)
+	}
+}
+
+func TestSyncGroupsOtherProvider(t *testing.T) {
+	db := newTestDB(t)
+	configureTestGroups(t)
+
+	user := &persistence.User{ID: "partner", Name: "Partner", Email: "partner@example.com", Role: model.RoleHost, Teams: persistence.StringArray{"Aarhus"}}
+	if err := db.Create(user).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	// Another directory can report the same group ids, they must not grant anything
+	for _, provider := range []string{"google", "unconfigured"} {
+		if err := syncGroups(db, provider, user, []string{adminsGroup, copenhagenGroup}); err != nil {
+			t.Fatal(err)
+		}
+		var stored persistence.User
+		if err := db.First(&stored, "id = ?", user.ID).Error; err != nil {
+			t.Fatal(err)
+		}
+		if stored.Role != model.RoleHost || !slices.Equal(stored.Teams, persistence.StringArray{"Aarhus"}) || stored.Version != 1 {
+			t.Errorf("%s: got role %s, teams %v and version %d, want the user unchanged", provider, stored.Role, stored.Teams, stored.Version)
+		}
+	}
+}
+
+func TestConfigureGroupMapping(t *testing.T) {
+	previous := groups
+	t.Cleanup(func() { groups = previous })
+
+	tests := []struct {
+		name  string
+		key   string
+		value string
+	}{
+		{"unscoped roles", "GROUP_ROLES", adminsGroup + "=ADMIN"},
+		{"unscoped teams", "GROUP_TEAMS", copenhagenGroup + "=Copenhagen"},
+		{"unknown role", "GROUP_ROLES_AZURE", adminsGroup + "=ROOT"},
+		{"no value", "GROUP_ROLES_AZURE", adminsGroup},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv(test.key, test.value)
+			if err := configureGroupMapping([]string{"azure"}); err == nil {
+				t.Errorf("%s=%s was accepted", test.key, test.value)
+			}
+		})
+	}
+
+	t.Setenv("GROUP_ROLES_GOOGLE", adminsGroup+"=ADMIN")
+	if err := configureGroupMapping([]string{"azure", "google"}); err != nil {
+		t.Fatal(err)
+	}
+	if groups["azure"].enabled() || !groups["google"].enabled() {
+		t.Errorf("got mapping %v, want only google mapped", groups)
+	}
+}
@@ -0,0 +1,37 @@
+package auth
+
+import (
+	"context"
+	"graphql-go/persistence"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+// newTestDB returns a migrated in-memory SQLite database and configures the signing keys
+// with it, which are shared by all tests of the package like they are by the server
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	t.Setenv("DB_URL", "sqlite::memory:")
+	db, err := persistence.ConnectGORM(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+
+	if err := persistence.EnsureMigrated(db, true); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	if err := ConfigureSigningKeys(ctx, db); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
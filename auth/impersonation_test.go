@@ -0,0 +1,132 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"graphql-go/graph/model"
+	"graphql-go/persistence"
+	"log"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// captureLog collects what the package logs until the test ends
+func captureLog(t *testing.T) *bytes.Buffer {
+	t.Helper()
+
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+	return &buf
+}
+
+func TestImpersonate(t *testing.T) {
+	db := newTestDB(t)
+	sessions := persistence.NewGormRepositories(db).Sessions
+	ctx := context.Background()
+	admin := addTestUser(t, db, "admin", model.RoleAdmin)
+	member := addTestUser(t, db, "member", model.RoleMember)
+	logged := captureLog(t)
+
+	token, ttl, err := Impersonate(ctx, sessions, admin, member)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ttl != defaultImpersonationTTL {
+		t.Errorf("got lifetime %s, want %s", ttl, defaultImpersonationTTL)
+	}
+	if !strings.Contains(logged.String(), "admin@example.com (admin) started acting as member@example.com (member)") {
+		t.Errorf("got log %q, want the impersonation recorded", logged.String())
+	}
+
+	// The token is the member's, and names the admin acting for them
+	claims, err := parseAuthorization("Bearer " + token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claims.Subject != member.ID || claims.Actor == nil || claims.Actor.Subject != admin.ID {
+		t.Errorf("got subject %s and actor %+v, want the member acted on by the admin", claims.Subject, claims.Actor)
+	}
+	authCtx, err := Authenticate(ctx, db, "Bearer "+token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ForContext(authCtx).ID != member.ID || ActorForContext(authCtx) == nil || ActorForContext(authCtx).ID != admin.ID {
+		t.Errorf("got user %s acted on by %v, want the member and the admin", ForContext(authCtx).ID, ActorForContext(authCtx))
+	}
+
+	// An admin who lost the role cannot go on impersonating
+	if err := db.Model(admin).Update("role", model.RoleMember).Error; err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Authenticate(ctx, db, "Bearer "+token); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("authenticating after the admin was demoted: got %v, want ErrInvalidToken", err)
+	}
+}
+
+func TestImpersonateRefused(t *testing.T) {
+	db := newTestDB(t)
+	sessions := persistence.NewGormRepositories(db).Sessions
+	admin := addTestUser(t, db, "admin", model.RoleAdmin)
+	host := addTestUser(t, db, "host", model.RoleHost)
+	member := addTestUser(t, db, "member", model.RoleMember)
+	if err := db.Model(member).Update("deactivated_at", time.Now()).Error; err != nil {
+		t.Fatal(err)
+	}
+	member.DeactivatedAt = new(time.Time)
+
+	tests := []struct {
+		name   string
+		actor  *persistence.User
+		target *persistence.User
+	}{
+		{"not an admin", host, admin},
+		{"themselves", admin, admin},
+		{"deactivated", admin, member},
+	}
+	for _, test := range tests {
+		if _, _, err := Impersonate(context.Background(), sessions, test.actor, test.target); err == nil {
+			t.Errorf("%s: impersonated", test.name)
+		}
+	}
+}
+
+func TestLogImpersonation(t *testing.T) {
+	admin := &persistence.User{ID: "admin", Email: "admin@example.com"}
+	member := &persistence.User{ID: "member", Email: "member@example.com"}
+	logged := captureLog(t)
+
+	run := func(ctx context.Context) {
+		ctx = graphql.WithOperationContext(ctx, &graphql.OperationContext{
+			RawQuery:      `mutation Order { orderBurger(burgerDayId: "day", specialRequest: []) { id } }`,
+			OperationName: "Order",
+			Operation:     &ast.OperationDefinition{Operation: ast.Mutation},
+			Variables:     map[string]interface{}{},
+		})
+		called := false
+		LogImpersonation(ctx, func(ctx context.Context) graphql.ResponseHandler {
+			called = true
+			return nil
+		})
+		if !called {
+			t.Error("the operation did not run")
+		}
+	}
+
+	run(WithUser(context.Background(), member))
+	if logged.Len() != 0 {
+		t.Errorf("logged %q for a user acting themselves", logged.String())
+	}
+
+	run(WithActor(WithUser(context.Background(), member), admin))
+	want := `admin@example.com (admin) as member@example.com (member) ran mutation "Order": mutation Order { orderBurger`
+	if !strings.Contains(logged.String(), want) {
+		t.Errorf("got log %q, want the operation recorded", logged.String())
+	}
+}
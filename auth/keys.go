@@ -0,0 +1,319 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"graphql-go/persistence"
+)
+
+const (
+	defaultSigningAlgorithm = "ES256"
+	defaultKeyRotation      = 30 * 24 * time.Hour
+	keyRefreshInterval      = time.Hour
+	// jwksMaxAge is how long clients may cache the JWKS
+	jwksMaxAge = 5 * time.Minute
+	// keyPublishDelay is how long a new key is published before it signs. By then every replica
+	// has loaded it and every cached JWKS has expired, so everyone verifying tokens knows it.
+	keyPublishDelay = keyRefreshInterval + jwksMaxAge
+	// minKeyReload limits how often tokens with an unknown kid reload the keys
+	minKeyReload = 10 * time.Second
+)
+
+// signingKey is a private key we sign access tokens with, identified by its kid
+type signingKey struct {
+	id        string
+	method    jwt.SigningMethod
+	private   crypto.Signer
+	createdAt time.Time
+}
+
+// keySet holds the signing keys shared by all replicas through the database. The newest key
+// that has been published long enough signs new tokens, older keys are kept for verification
+// until every token they signed expired.
+type keySet struct {
+	db        *gorm.DB
+	algorithm string
+	rotation  time.Duration
+
+	mutex    sync.RWMutex
+	keys     []*signingKey // newest first
+	loadedAt time.Time
+}
+
+// keys is the key set configured at startup by ConfigureSigningKeys
+var keys *keySet
+
+// ConfigureSigningKeys loads the signing keys from the database, creating the first one if
+// needed, and starts rotating them in the background until the context is done.
+//
+// JWT_SIGNING_ALG selects RS256 or ES256 (the default) for new keys and SIGNING_KEY_ROTATION
+// (e.g. "720h") how often a new key is introduced.
+func ConfigureSigningKeys(ctx context.Context, db *gorm.DB) error {
+	algorithm := os.Getenv("JWT_SIGNING_ALG")
+	if algorithm == "" {
+		algorithm = defaultSigningAlgorithm
+	}
+	if algorithm != "RS256" && algorithm != "ES256" {
+		return fmt.Errorf("unsupported JWT_SIGNING_ALG: %s", algorithm)
+	}
+
+	set := &keySet{
+		db:        db,
+		algorithm: algorithm,
+		rotation:  durationFromEnv("SIGNING_KEY_ROTATION", defaultKeyRotation),
+	}
+	if err := set.rotate(); err != nil {
+		return err
+	}
+
+	keys = set
+	go set.rotateEvery(ctx, keyRefreshInterval)
+	return nil
+}
+
+// rotateEvery periodically reloads the keys, picking up keys created by other replicas,
+// and introduces a new key when the newest one is due for rotation
+func (s *keySet) rotateEvery(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.rotate(); err != nil {
+				log.Printf("rotating signing keys failed: %v", err)
+			}
+		}
+	}
+}
+
+// rotate reloads the keys, creates the successor of the signing key ahead of time so it is
+// published when the rotation interval is up, and deletes keys that can no longer have signed
+// a valid token
+func (s *keySet) rotate() error {
+	if err := s.load(); err != nil {
+		return err
+	}
+
+	s.mutex.RLock()
+	due := len(s.keys) == 0 || time.Since(s.keys[0].createdAt) >= s.rotation-keyPublishDelay
+	s.mutex.RUnlock()
+
+	if due {
+		if err := s.create(); err != nil {
+			return err
+		}
+		if err := s.load(); err != nil {
+			return err
+		}
+	}
+
+	// A key stops signing once its successor does, at most one refresh interval after the
+	// rotation is up, and is needed for verification until the last token it signed expired
+	signedUntil := max(s.rotation, keyPublishDelay) + keyRefreshInterval
+	retiredBefore := time.Now().Add(-signedUntil - maxTokenTTL())
+	return s.db.Where("created_at < ?", retiredBefore).Delete(&persistence.SigningKey{}).Error
+}
+
+// maxTokenTTL is the longest any token signed with the keys stays valid
+func maxTokenTTL() time.Duration {
+	return max(accessTokenTTL(), impersonationTTL(), magicLinkTTL())
+}
+
+func (s *keySet) load() error {
+	var rows []*persistence.SigningKey
+	if err := s.db.Order("created_at desc").Find(&rows).Error; err != nil {
+		return err
+	}
+
+	loaded := make([]*signingKey, 0, len(rows))
+	for _, row := range rows {
+		key, err := decodeSigningKey(row)
+		if err != nil {
+			return fmt.Errorf("decoding signing key %s: %w", row.ID, err)
+		}
+		loaded = append(loaded, key)
+	}
+
+	s.mutex.Lock()
+	s.keys = loaded
+	s.loadedAt = time.Now()
+	s.mutex.Unlock()
+	return nil
+}
+
+func (s *keySet) create() error {
+	var private crypto.Signer
+	var err error
+	switch s.algorithm {
+	case "RS256":
+		private, err = rsa.GenerateKey(rand.Reader, 2048)
+	case "ES256":
+		private, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	}
+	if err != nil {
+		return err
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Create(&persistence.SigningKey{
+		ID:         uuid.New().String(),
+		Algorithm:  s.algorithm,
+		PrivateKey: string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+	}).Error
+}
+
+func decodeSigningKey(row *persistence.SigningKey) (*signingKey, error) {
+	block, _ := pem.Decode([]byte(row.PrivateKey))
+	if block == nil {
+		return nil, errors.New("invalid PEM")
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	private, ok := parsed.(crypto.Signer)
+	if !ok {
+		return nil, errors.New("key cannot sign")
+	}
+
+	method := jwt.GetSigningMethod(row.Algorithm)
+	if method == nil {
+		return nil, fmt.Errorf("unknown algorithm %s", row.Algorithm)
+	}
+
+	return &signingKey{id: row.ID, method: method, private: private, createdAt: row.CreatedAt}, nil
+}
+
+// current returns the key new tokens are signed with: the newest key that has been published
+// for keyPublishDelay. Only the very first key signs right away, as there is no other.
+func (s *keySet) current() (*signingKey, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if len(s.keys) == 0 {
+		return nil, errors.New("no signing keys configured")
+	}
+	for _, key := range s.keys {
+		if time.Since(key.createdAt) >= keyPublishDelay {
+			return key, nil
+		}
+	}
+	return s.keys[len(s.keys)-1], nil
+}
+
+// find returns the key with the given kid. Unknown kids reload the keys in case they were
+// restored or created by hand, but at most every minKeyReload, so garbage kids cannot keep
+// the database busy.
+func (s *keySet) find(kid string) (*signingKey, error) {
+	if key := s.lookup(kid); key != nil {
+		return key, nil
+	}
+
+	s.mutex.RLock()
+	recent := time.Since(s.loadedAt) < minKeyReload
+	s.mutex.RUnlock()
+	if !recent {
+		if err := s.load(); err != nil {
+			return nil, err
+		}
+		if key := s.lookup(kid); key != nil {
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown signing key: %s", kid)
+}
+
+func (s *keySet) lookup(kid string) *signingKey {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	for _, key := range s.keys {
+		if key.id == kid {
+			return key
+		}
+	}
+	return nil
+}
+
+// sign signs the claims with the current key, setting the kid header
+func (s *keySet) sign(claims jwt.Claims) (string, error) {
+	key, err := s.current()
+	if err != nil {
+		return "", err
+	}
+
+	token := jwt.NewWithClaims(key.method, claims)
+	token.Header["kid"] = key.id
+	return token.SignedString(key.private)
+}
+
+// keyFunc resolves the verification key of a token from its kid header
+func (s *keySet) keyFunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	key, err := s.find(kid)
+	if err != nil {
+		return nil, err
+	}
+
+	// Make sure the token method is what we expect
+	if token.Method.Alg() != key.method.Alg() {
+		return nil, fmt.Errorf("Unexpected signing method: %v", token.Header["alg"])
+	}
+
+	return key.private.Public(), nil
+}
+
+// jwks returns the public halves of all keys in the set
+func (s *keySet) jwks() (*JWKS, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	set := &JWKS{Keys: make([]JWK, 0, len(s.keys))}
+	for _, key := range s.keys {
+		jwk, err := NewJWK(key.id, key.method.Alg(), key.private.Public())
+		if err != nil {
+			return nil, err
+		}
+		set.Keys = append(set.Keys, *jwk)
+	}
+	return set, nil
+}
+
+// HandleJWKS serves the public signing keys at /.well-known/jwks.json, so other services
+// can verify our access tokens
+func HandleJWKS(w http.ResponseWriter, r *http.Request) {
+	set, err := keys.jwks()
+	if err != nil {
+		http.Error(w, "Failed to encode keys: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(jwksMaxAge.Seconds())))
+	json.NewEncoder(w).Encode(set)
+}
@@ -0,0 +1,142 @@
+package auth
+
+import (
+	"graphql-go/persistence"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// addKey stores a new signing key that was created age ago and returns its id
+func addKey(t *testing.T, set *keySet, age time.Duration) string {
+	t.Helper()
+
+	if err := set.create(); err != nil {
+		t.Fatal(err)
+	}
+	var row persistence.SigningKey
+	if err := set.db.Order("created_at desc").First(&row).Error; err != nil {
+		t.Fatal(err)
+	}
+	setAge(t, set, row.ID, age)
+	return row.ID
+}
+
+func setAge(t *testing.T, set *keySet, id string, age time.Duration) {
+	t.Helper()
+
+	err := set.db.Model(&persistence.SigningKey{}).Where("id = ?", id).Update("created_at", time.Now().Add(-age)).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func newTestKeySet(t *testing.T, db *gorm.DB, rotation time.Duration) *keySet {
+	t.Helper()
+
+	// Start from an empty set rather than the key newTestDB configured
+	if err := db.Where("1 = 1").Delete(&persistence.SigningKey{}).Error; err != nil {
+		t.Fatal(err)
+	}
+	return &keySet{db: db, algorithm: "ES256", rotation: rotation}
+}
+
+func storedKeyIDs(t *testing.T, db *gorm.DB) map[string]bool {
+	t.Helper()
+
+	var rows []persistence.SigningKey
+	if err := db.Find(&rows).Error; err != nil {
+		t.Fatal(err)
+	}
+	ids := map[string]bool{}
+	for _, row := range rows {
+		ids[row.ID] = true
+	}
+	return ids
+}
+
+func TestNewKeysArePublishedBeforeTheySign(t *testing.T) {
+	set := newTestKeySet(t, newTestDB(t), 24*time.Hour)
+
+	// The only key signs right away
+	first := addKey(t, set, 0)
+	if err := set.load(); err != nil {
+		t.Fatal(err)
+	}
+	if key, _ := set.current(); key.id != first {
+		t.Fatalf("signing with %s, want the only key %s", key.id, first)
+	}
+
+	// Its successor is created ahead of the rotation, but only signs once published long enough
+	setAge(t, set, first, 24*time.Hour-keyPublishDelay+time.Minute)
+	if err := set.rotate(); err != nil {
+		t.Fatal(err)
+	}
+	published, err := set.jwks()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(published.Keys) != 2 {
+		t.Fatalf("published %d keys, want 2", len(published.Keys))
+	}
+	successor := set.keys[0].id
+	if key, _ := set.current(); key.id != first {
+		t.Fatalf("signing with %s, want %s until its successor is published long enough", key.id, first)
+	}
+
+	setAge(t, set, successor, keyPublishDelay)
+	if err := set.load(); err != nil {
+		t.Fatal(err)
+	}
+	if key, _ := set.current(); key.id != successor {
+		t.Fatalf("signing with %s, want the successor %s", key.id, successor)
+	}
+}
+
+func TestRotateKeepsKeysForTheLongestTokenTTL(t *testing.T) {
+	t.Setenv("ACCESS_TOKEN_TTL", "15m")
+	t.Setenv("IMPERSONATION_TTL", "48h")
+	t.Setenv("MAGIC_LINK_TTL", "30m")
+	set := newTestKeySet(t, newTestDB(t), 24*time.Hour)
+
+	// Retired 25h after creation, the impersonation tokens it signed last another 48h
+	kept := addKey(t, set, 70*time.Hour)
+	expired := addKey(t, set, 80*time.Hour)
+	current := addKey(t, set, 2*time.Hour)
+
+	if err := set.rotate(); err != nil {
+		t.Fatal(err)
+	}
+	ids := storedKeyIDs(t, set.db)
+	if !ids[kept] || !ids[current] {
+		t.Error("deleted a key that may have signed a valid token")
+	}
+	if ids[expired] {
+		t.Error("kept a key all of whose tokens expired")
+	}
+}
+
+func TestUnknownKidReloadsAtMostEveryMinKeyReload(t *testing.T) {
+	set := newTestKeySet(t, newTestDB(t), 24*time.Hour)
+	addKey(t, set, 2*time.Hour)
+	if err := set.load(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Created by another replica right after we loaded
+	other := addKey(t, set, 0)
+	if _, err := set.find(other); err == nil {
+		t.Fatal("reloaded the keys right after loading them")
+	}
+
+	set.mutex.Lock()
+	set.loadedAt = time.Now().Add(-minKeyReload)
+	set.mutex.Unlock()
+	if _, err := set.find(other); err != nil {
+		t.Fatalf("did not reload for an unknown kid: %v", err)
+	}
+	if _, err := set.find("garbage"); err == nil {
+		t.Fatal("found a key that does not exist")
+	}
+}
@@ -0,0 +1,55 @@
+package auth
+
+import (
+	"sync"
+	"time"
+)
+
+// requestLimiter allows a number of requests per key, like an email address or a client
+// address, within a fixed window
+type requestLimiter struct {
+	limit  int
+	window time.Duration
+
+	mutex   sync.Mutex
+	windows map[string]*limitWindow
+	sweptAt time.Time
+}
+
+type limitWindow struct {
+	start time.Time
+	count int
+}
+
+func newRequestLimiter(limit int, window time.Duration) *requestLimiter {
+	return &requestLimiter{limit: limit, window: window, windows: map[string]*limitWindow{}}
+}
+
+// allow counts a request for the key and reports whether it is within the limit. Requests
+// over the limit are not counted, so the window of a key ends when it started.
+func (l *requestLimiter) allow(key string) bool {
+	now := time.Now()
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	// Expired windows are dropped once per window, so keys seen once do not pile up
+	if now.Sub(l.sweptAt) >= l.window {
+		for k, w := range l.windows {
+			if now.Sub(w.start) >= l.window {
+				delete(l.windows, k)
+			}
+		}
+		l.sweptAt = now
+	}
+
+	w, ok := l.windows[key]
+	if !ok || now.Sub(w.start) >= l.window {
+		w = &limitWindow{start: now}
+		l.windows[key] = w
+	}
+	if w.count >= l.limit {
+		return false
+	}
+	w.count++
+	return true
+}
@@ -0,0 +1,96 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"graphql-go/persistence"
+	"net/http"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// loginCodeTTL is how long the frontend has to exchange a login code for tokens
+const loginCodeTTL = time.Minute
+
+var ErrInvalidLoginCode = errors.New("Invalid login code")
+
+// CreateLoginCode returns a single-use code the frontend can exchange for a token pair of the user
+func CreateLoginCode(db *gorm.DB, user *persistence.User) (string, error) {
+	code, err := generateRefreshToken()
+	if err != nil {
+		return "", err
+	}
+
+	err = db.Create(&persistence.LoginCode{
+		ID:        hashToken(code),
+		UserId:    user.ID,
+		ExpiresAt: time.Now().Add(loginCodeTTL),
+	}).Error
+	if err != nil {
+		return "", err
+	}
+
+	return code, nil
+}
+
+// ExchangeLoginCode consumes the login code and returns the user it was created for
+func ExchangeLoginCode(db *gorm.DB, code string) (*persistence.User, error) {
+	loginCode := &persistence.LoginCode{}
+	res := db.Where("id = ?", hashToken(code)).Limit(1).Find(loginCode)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return nil, ErrInvalidLoginCode
+	}
+
+	// Deleting the row is what consumes the code, so only one of two concurrent exchanges wins
+	res = db.Delete(loginCode)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	if res.RowsAffected == 0 || time.Now().After(loginCode.ExpiresAt) {
+		return nil, ErrInvalidLoginCode
+	}
+
+	return activeUser(db, loginCode.UserId)
+}
+
+// HandleTokenExchange exchanges a login code, posted as {"code": "..."}, for a token pair
+func HandleTokenExchange(db *gorm.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		db := db.WithContext(r.Context())
+
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		var body struct {
+			Code string `json:"code"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Code == "" {
+			http.Error(w, "Missing login code", http.StatusBadRequest)
+			return
+		}
+
+		user, err := ExchangeLoginCode(db, body.Code)
+		if errors.Is(err, ErrInvalidLoginCode) || errors.Is(err, ErrDeactivated) {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		if err != nil {
+			http.Error(w, "Failed to exchange login code: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		pair, err := IssueTokens(db, user)
+		if err != nil {
+			http.Error(w, "Failed to sign token: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		writeTokenPair(w, pair)
+	}
+}
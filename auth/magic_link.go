@@ -0,0 +1,315 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"graphql-go/graph/model"
+	"graphql-go/mailer"
+	"graphql-go/persistence"
+	"html/template"
+	"log"
+	"net"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	defaultMagicLinkTTL = 15 * time.Minute
+	// magicLinkAudience keeps login links from being accepted anywhere else
+	magicLinkAudience = "magic-link"
+	// magicLinkProvider is the provider of the identities guests log in with, the subject is
+	// their email
+	magicLinkProvider = "magic-link"
+	// Links are limited per email, so nobody can flood a mailbox with them, and per client
+	// address, so nobody can flood many mailboxes
+	magicLinksPerEmail   = 3
+	magicLinksPerClient  = 20
+	magicLinkLimitWindow = 15 * time.Minute
+	magicLinkSendTimeout = 30 * time.Second
+)
+
+var ErrInvalidMagicLink = errors.New("Invalid or expired login link")
+
+// magicLinks holds the magic link configuration set up at startup
+var magicLinks struct {
+	sender    mailer.Sender
+	publicURL string
+	perEmail  *requestLimiter
+	perClient *requestLimiter
+}
+
+// magicLinkClaims are the claims of login links. They have no session id, so they are never
+// accepted as access tokens.
+type magicLinkClaims struct {
+	jwt.RegisteredClaims
+	RedirectURL string `json:"redirect_url"`
+	Mode        string `json:"mode,omitempty"`
+}
+
+// ConfigureMagicLinks enables guest logins with emailed links, sent with the sender. The links
+// point at publicURL, the address this server is reachable at, e.g. https://burger.example.com.
+func ConfigureMagicLinks(sender mailer.Sender, publicURL string) error {
+	if publicURL == "" {
+		return errors.New("PUBLIC_URL is required for login links")
+	}
+	magicLinks.sender = sender
+	magicLinks.publicURL = strings.TrimSuffix(publicURL, "/")
+	magicLinks.perEmail = newRequestLimiter(magicLinksPerEmail, magicLinkLimitWindow)
+	magicLinks.perClient = newRequestLimiter(magicLinksPerClient, magicLinkLimitWindow)
+	return nil
+}
+
+// magicLinkTTL reads MAGIC_LINK_TTL (e.g. "15m"), falling back to the default
+func magicLinkTTL() time.Duration {
+	return durationFromEnv("MAGIC_LINK_TTL", defaultMagicLinkTTL)
+}
+
+// guestEmailAllowed checks the email against the comma separated domains in
+// MAGIC_LINK_DOMAINS. No domain is allowed when it is not set, as anyone could then sign up.
+func guestEmailAllowed(email string) bool {
+	domains := strings.TrimSpace(os.Getenv("MAGIC_LINK_DOMAINS"))
+	if domains == "" {
+		return false
+	}
+
+	_, domain, _ := strings.Cut(email, "@")
+	for _, allowed := range strings.Split(domains, ",") {
+		if strings.EqualFold(strings.TrimSpace(allowed), domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// guestForEmail returns the guest user with the email, nil if there is none yet. Users with a
+// corporate account log in through their identity provider, so links are never sent to them.
+func guestForEmail(db *gorm.DB, email string) (*persistence.User, error) {
+	user := &persistence.User{}
+	res := db.Where("LOWER(email) = LOWER(?)", email).Limit(1).Find(user)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return nil, nil
+	}
+	if user.Role != model.RoleGuest || user.ServiceAccount || user.DeactivatedAt != nil {
+		return nil, ErrInvalidMagicLink
+	}
+	return user, nil
+}
+
+// SendMagicLink emails a login link to the guest. Nothing is sent to emails that belong to
+// a corporate account or a domain that is not allowed.
+func SendMagicLink(ctx context.Context, db *gorm.DB, email string, redirectURL string, mode string) error {
+	if magicLinks.sender == nil {
+		return errors.New("login links are not configured")
+	}
+	if !guestEmailAllowed(email) {
+		return nil
+	}
+	if _, err := guestForEmail(db.WithContext(ctx), email); err != nil {
+		if errors.Is(err, ErrInvalidMagicLink) {
+			return nil
+		}
+		return err
+	}
+
+	now := time.Now()
+	token, err := keys.sign(magicLinkClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.New().String(),
+			Subject:   email,
+			Audience:  jwt.ClaimStrings{magicLinkAudience},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(magicLinkTTL())),
+		},
+		RedirectURL: redirectURL,
+		Mode:        mode,
+	})
+	if err != nil {
+		return err
+	}
+
+	link := magicLinks.publicURL + "/auth/magic-link/verify?token=" + url.QueryEscape(token)
+	return magicLinks.sender.Send(ctx, mailer.Message{
+		To:      email,
+		Subject: "Your burger login link",
+		Body: fmt.Sprintf("Open this link to log in:\n\n%s\n\nThe link works once and expires in %s. "+
+			"If you did not ask for it, you can ignore this email.\n", link, magicLinkTTL()),
+	})
+}
+
+// RedeemMagicLink verifies the login link token and consumes it. It returns the guest user,
+// created on first login, and where to send them.
+func RedeemMagicLink(db *gorm.DB, token string) (*persistence.User, *magicLinkClaims, error) {
+	claims := &magicLinkClaims{}
+	_, err := jwt.ParseWithClaims(token, claims, keys.keyFunc,
+		jwt.WithValidMethods([]string{"RS256", "ES256"}),
+		jwt.WithAudience(magicLinkAudience),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil || claims.ID == "" {
+		return nil, nil, ErrInvalidMagicLink
+	}
+
+	var user *persistence.User
+	err = db.Transaction(func(tx *gorm.DB) error {
+		// Revoking the token id is what consumes the link, a second use fails on the primary key
+		if err := tx.Create(&persistence.RevokedToken{ID: claims.ID, ExpiresAt: claims.ExpiresAt.Time}).Error; err != nil {
+			return ErrInvalidMagicLink
+		}
+
+		var err error
+		user, err = guestForEmail(tx, claims.Subject)
+		if err != nil {
+			return err
+		}
+
+		if user == nil {
+			name, _, _ := strings.Cut(claims.Subject, "@")
+			user = &persistence.User{
+				ID:    uuid.New().String(),
+				Name:  name,
+				Email: claims.Subject,
+				Role:  model.RoleGuest,
+			}
+			if err := tx.Create(user).Error; err != nil {
+				return err
+			}
+		}
+
+		// Like provider logins, the guest gets an identity, so no provider login is ever linked
+		// to them by email. Guests from before identities were recorded get theirs now.
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&persistence.UserIdentity{
+			Provider: magicLinkProvider,
+			Subject:  strings.ToLower(claims.Subject),
+			UserId:   user.ID,
+		}).Error
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return user, claims, nil
+}
+
+// HandleMagicLinkRequest emails a login link, posted as
+// {"email": "...", "redirectUrl": "...", "mode": "token" | "cookie"}. It answers 202 whether or
+// not a link is sent, before sending it, so neither the answer nor how long it takes tells who
+// has an account. Too many requests for an email or from a client are answered with 429.
+func HandleMagicLinkRequest(db *gorm.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		var body struct {
+			Email       string `json:"email"`
+			RedirectURL string `json:"redirectUrl"`
+			Mode        string `json:"mode"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+
+		address, err := mail.ParseAddress(body.Email)
+		if err != nil || address.Address != body.Email {
+			http.Error(w, "Invalid email", http.StatusBadRequest)
+			return
+		}
+		if !allowedRedirect(body.RedirectURL) {
+			http.Error(w, "Invalid redirectUrl", http.StatusBadRequest)
+			return
+		}
+		if body.Mode != "" && body.Mode != "cookie" && body.Mode != "token" {
+			http.Error(w, "Invalid mode", http.StatusBadRequest)
+			return
+		}
+
+		// Limits count every request, whether or not the email may get a link
+		clientIP, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			clientIP = r.RemoteAddr
+		}
+		if !magicLinks.perClient.allow(clientIP) || !magicLinks.perEmail.allow(strings.ToLower(body.Email)) {
+			w.Header().Set("Retry-After", strconv.Itoa(int(magicLinkLimitWindow.Seconds())))
+			http.Error(w, "Too many login links requested, try again later", http.StatusTooManyRequests)
+			return
+		}
+
+		// The link is sent after answering, the request being done must not cancel it
+		ctx := context.WithoutCancel(r.Context())
+		go func() {
+			ctx, cancel := context.WithTimeout(ctx, magicLinkSendTimeout)
+			defer cancel()
+			if err := SendMagicLink(ctx, db, body.Email, body.RedirectURL, body.Mode); err != nil {
+				log.Printf("sending login link failed: %v", err)
+			}
+		}()
+
+		w.WriteHeader(http.StatusAccepted)
+	}
+}
+
+// magicLinkPage asks for a click before the link is used, since mail scanners open links
+// to check them and would otherwise use up the link
+var magicLinkPage = template.Must(template.New("magic-link").Parse(`<!DOCTYPE html>
+<html>
+<head><title>Log in</title></head>
+<body>
+<form method="post" action="/auth/magic-link/verify">
+<input type="hidden" name="token" value="{{.}}">
+<button type="submit">Log in</button>
+</form>
+</body>
+</html>
+`))
+
+// HandleMagicLinkVerify shows a log in button for the emailed link on GET, and completes the
+// login like the OAuth callback on POST
+func HandleMagicLinkVerify(db *gorm.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		db := db.WithContext(r.Context())
+
+		switch r.Method {
+		case http.MethodGet:
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.Header().Set("Referrer-Policy", "no-referrer")
+			magicLinkPage.Execute(w, r.URL.Query().Get("token"))
+		case http.MethodPost:
+			user, claims, err := RedeemMagicLink(db, r.PostFormValue("token"))
+			if errors.Is(err, ErrInvalidMagicLink) {
+				http.Error(w, err.Error(), http.StatusUnauthorized)
+				return
+			}
+			if err != nil {
+				http.Error(w, "Login failed: "+err.Error(), http.StatusInternalServerError)
+				return
+			}
+
+			// Check the redirect again in case the allowlist changed
+			if !allowedRedirect(claims.RedirectURL) {
+				http.Error(w, "Invalid redirect_url", http.StatusBadRequest)
+				return
+			}
+
+			completeLogin(w, r, db, user, claims.RedirectURL, claims.Mode == "cookie")
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	}
+}
@@ -0,0 +1,289 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"graphql-go/graph/model"
+	"graphql-go/mailer"
+	"graphql-go/persistence"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// recordingSender keeps the messages instead of sending them
+type recordingSender struct {
+	mutex    sync.Mutex
+	messages []mailer.Message
+}
+
+func (s *recordingSender) Send(ctx context.Context, msg mailer.Message) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.messages = append(s.messages, msg)
+	return nil
+}
+
+// waitFor waits until n messages were sent in the background, and a little longer for more
+func (s *recordingSender) waitFor(t *testing.T, n int) []mailer.Message {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		s.mutex.Lock()
+		sent := len(s.messages)
+		s.mutex.Unlock()
+		if sent >= n || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if len(s.messages) != n {
+		t.Fatalf("sent %d messages, want %d", len(s.messages), n)
+	}
+	return append([]mailer.Message(nil), s.messages...)
+}
+
+func configureTestMagicLinks(t *testing.T) *recordingSender {
+	t.Helper()
+
+	previous := magicLinks
+	t.Cleanup(func() { magicLinks = previous })
+
+	sender := &recordingSender{}
+	if err := ConfigureMagicLinks(sender, "https://burger.example.com/"); err != nil {
+		t.Fatal(err)
+	}
+	return sender
+}
+
+func TestGuestEmailAllowed(t *testing.T) {
+	tests := []struct {
+		domains string
+		email   string
+		allowed bool
+	}{
+		{"", "guest@example.com", false},
+		{" ", "guest@example.com", false},
+		{"example.com", "guest@example.com", true},
+		{"example.com", "guest@EXAMPLE.com", true},
+		{"partner.com, example.com", "guest@example.com", true},
+		{"example.com", "guest@evil.com", false},
+		{"example.com", "guest@sub.example.com", false},
+		{"example.com", "guest", false},
+	}
+	for _, test := range tests {
+		t.Setenv("MAGIC_LINK_DOMAINS", test.domains)
+		if got := guestEmailAllowed(test.email); got != test.allowed {
+			t.Errorf("guestEmailAllowed(%q) with MAGIC_LINK_DOMAINS=%q = %v, want %v", test.email, test.domains, got, test.allowed)
+		}
+	}
+}
+
+var magicLinkPattern = regexp.MustCompile(`https://burger\.example\.com/auth/magic-link/verify\?token=(\S+)`)
+
+func TestMagicLinkLogin(t *testing.T) {
+	db := newTestDB(t)
+	sender := configureTestMagicLinks(t)
+	t.Setenv("MAGIC_LINK_DOMAINS", "example.com")
+	ctx := context.Background()
+
+	if err := SendMagicLink(ctx, db, "guest@example.com", "https://app.example.com/", "cookie"); err != nil {
+		t.Fatalf("SendMagicLink failed: %v", err)
+	}
+	if len(sender.messages) != 1 {
+		t.Fatalf("sent %d messages, want 1", len(sender.messages))
+	}
+	msg := sender.messages[0]
+	if msg.To != "guest@example.com" {
+		t.Errorf("sent to %q", msg.To)
+	}
+	match := magicLinkPattern.FindStringSubmatch(msg.Body)
+	if match == nil {
+		t.Fatalf("body has no login link: %q", msg.Body)
+	}
+	token, err := url.QueryUnescape(match[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	user, claims, err := RedeemMagicLink(db, token)
+	if err != nil {
+		t.Fatalf("RedeemMagicLink failed: %v", err)
+	}
+	if user.Email != "guest@example.com" || user.Role != model.RoleGuest {
+		t.Errorf("got user %s with role %s, want a guest", user.Email, user.Role)
+	}
+	if claims.RedirectURL != "https://app.example.com/" || claims.Mode != "cookie" {
+		t.Errorf("got redirect %q in mode %q", claims.RedirectURL, claims.Mode)
+	}
+
+	// The guest has an identity, so a provider vouching for the email gets a user of its own
+	var identity persistence.UserIdentity
+	if err := db.First(&identity, "provider = ? AND subject = ?", magicLinkProvider, "guest@example.com").Error; err != nil {
+		t.Fatalf("guest has no identity: %v", err)
+	}
+	if identity.UserId != user.ID {
+		t.Errorf("identity belongs to %s, want %s", identity.UserId, user.ID)
+	}
+	linked, err := upsertUser(db, "google", &Identity{Subject: "g-1", Email: "guest@example.com", EmailVerified: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if linked.ID == user.ID {
+		t.Error("provider login was linked to the guest by email")
+	}
+
+	if _, _, err := RedeemMagicLink(db, token); !errors.Is(err, ErrInvalidMagicLink) {
+		t.Errorf("second use of the link: got %v, want ErrInvalidMagicLink", err)
+	}
+
+	// A second link logs in the same guest
+	if err := SendMagicLink(ctx, db, "guest@example.com", "https://app.example.com/", ""); err != nil {
+		t.Fatal(err)
+	}
+	match = magicLinkPattern.FindStringSubmatch(sender.messages[1].Body)
+	token, _ = url.QueryUnescape(match[1])
+	again, _, err := RedeemMagicLink(db, token)
+	if err != nil {
+		t.Fatalf("RedeemMagicLink failed: %v", err)
+	}
+	if again.ID != user.ID {
+		t.Errorf("second login created user %s, want %s", again.ID, user.ID)
+	}
+}
+
+func TestMagicLinkNotSent(t *testing.T) {
+	db := newTestDB(t)
+	sender := configureTestMagicLinks(t)
+	ctx := context.Background()
+
+	member := &persistence.User{ID: "member", Email: "member@example.com", Role: model.RoleMember}
+	if err := db.Create(member).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	// Closed until domains are allowed
+	t.Setenv("MAGIC_LINK_DOMAINS", "")
+	if err := SendMagicLink(ctx, db, "guest@example.com", "/", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("MAGIC_LINK_DOMAINS", "example.com")
+	for _, email := range []string{"guest@elsewhere.com", "member@example.com", "MEMBER@example.com"} {
+		if err := SendMagicLink(ctx, db, email, "/", ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(sender.messages) != 0 {
+		t.Errorf("sent %d messages, want none", len(sender.messages))
+	}
+}
+
+// requestMagicLink posts a login link request for the email from the client address
+func requestMagicLink(db *gorm.DB, email string, remoteAddr string) int {
+	body := `{"email": "` + email + `", "redirectUrl": "/"}`
+	req := httptest.NewRequest(http.MethodPost, "/auth/magic-link", strings.NewReader(body))
+	req.RemoteAddr = remoteAddr
+	rec := httptest.NewRecorder()
+	HandleMagicLinkRequest(db)(rec, req)
+	return rec.Code
+}
+
+func TestMagicLinkRequest(t *testing.T) {
+	db := newTestDB(t)
+	sender := configureTestMagicLinks(t)
+	t.Setenv("MAGIC_LINK_DOMAINS", "example.com")
+
+	member := &persistence.User{ID: "member", Email: "member@example.com", Role: model.RoleMember}
+	if err := db.Create(member).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	// The answer is the same whether or not a link is sent
+	for _, email := range []string{"guest@example.com", "member@example.com", "guest@elsewhere.com"} {
+		if status := requestMagicLink(db, email, "192.0.2.1:1234"); status != http.StatusAccepted {
+			t.Errorf("%s: got status %d, want 202", email, status)
+		}
+	}
+	if msg := sender.waitFor(t, 1)[0]; msg.To != "guest@example.com" {
+		t.Errorf("sent to %s, want the guest", msg.To)
+	}
+
+	for _, body := range []string{`{"email": "not an email", "redirectUrl": "/"}`, `{"email": "guest@example.com", "redirectUrl": "//evil.example.com"}`} {
+		req := httptest.NewRequest(http.MethodPost, "/auth/magic-link", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+		HandleMagicLinkRequest(db)(rec, req)
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: got status %d, want 400", body, rec.Code)
+		}
+	}
+}
+
+func TestMagicLinkRequestLimited(t *testing.T) {
+	db := newTestDB(t)
+	sender := configureTestMagicLinks(t)
+	t.Setenv("MAGIC_LINK_DOMAINS", "example.com")
+
+	// Each email gets a few links, wherever they are requested from
+	for i := 0; i < magicLinksPerEmail; i++ {
+		if status := requestMagicLink(db, "guest@example.com", fmt.Sprintf("192.0.2.%d:1234", i)); status != http.StatusAccepted {
+			t.Fatalf("request %d: got status %d, want 202", i, status)
+		}
+	}
+	if status := requestMagicLink(db, "GUEST@example.com", "198.51.100.1:1234"); status != http.StatusTooManyRequests {
+		t.Errorf("one link too many for the email: got status %d, want 429", status)
+	}
+	sender.waitFor(t, magicLinksPerEmail)
+
+	// Each client gets a few more, for any emails, allowed or not
+	for i := 1; i < magicLinksPerClient; i++ {
+		if status := requestMagicLink(db, fmt.Sprintf("guest%d@elsewhere.com", i), "192.0.2.0:4321"); status != http.StatusAccepted {
+			t.Fatalf("request %d: got status %d, want 202", i, status)
+		}
+	}
+	if status := requestMagicLink(db, "other@example.com", "192.0.2.0:4321"); status != http.StatusTooManyRequests {
+		t.Errorf("one link too many for the client: got status %d, want 429", status)
+	}
+	if status := requestMagicLink(db, "other@example.com", "203.0.113.1:1234"); status != http.StatusAccepted {
+		t.Errorf("another client: got status %d, want 202", status)
+	}
+	sender.waitFor(t, magicLinksPerEmail+1)
+}
+
+func TestRequestLimiter(t *testing.T) {
+	limiter := newRequestLimiter(2, time.Hour)
+	for i, want := range []bool{true, true, false, false} {
+		if got := limiter.allow("a"); got != want {
+			t.Errorf("request %d: got %v, want %v", i, got, want)
+		}
+	}
+	if !limiter.allow("b") {
+		t.Error("another key was limited")
+	}
+
+	// The window starts over once it passed
+	limiter.windows["a"].start = time.Now().Add(-time.Hour)
+	if !limiter.allow("a") {
+		t.Error("limited after the window passed")
+	}
+	limiter.sweptAt = time.Now().Add(-time.Hour)
+	limiter.windows["b"].start = time.Now().Add(-time.Hour)
+	limiter.allow("c")
+	if _, ok := limiter.windows["b"]; ok {
+		t.Error("expired window was kept")
+	}
+}
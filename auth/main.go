@@ -1,200 +1,312 @@
 package auth
 
 import (
-	"context"
-	"encoding/json"
-	"fmt"
+	"errors"
 	"graphql-go/persistence"
 	"net/url"
 	"os"
-	"time"
+	"strings"
+	"unicode"
 
-	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
+	"golang.org/x/oauth2"
+	"gorm.io/gorm"
 
 	"crypto/rand"
+	"crypto/subtle"
 	"encoding/base64"
 	"net/http"
-
-	"golang.org/x/oauth2"
-	"golang.org/x/oauth2/microsoft"
 )
 
-var oauth2Config = &oauth2.Config{
-	ClientID:     os.Getenv("AZURE_CLIENT_ID"),
-	ClientSecret: os.Getenv("AZURE_CLIENT_SECRET"),
-	RedirectURL:  os.Getenv("AZURE_REDIRECT_URL"),
-	Scopes:       []string{"https://graph.microsoft.com/.default"},
-	Endpoint:     microsoft.AzureADEndpoint(os.Getenv("AZURE_TENANT_ID")),
+// loginCookieMaxAge bounds how long a user may take at the identity provider, in seconds
+const loginCookieMaxAge = 300
+
+// setLoginCookie stores a value needed to complete the login in the callback. The cookies are
+// short-lived, not readable by scripts and SameSite=Lax so they survive the redirect back from
+// the identity provider.
+func setLoginCookie(w http.ResponseWriter, name string, value string) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     name,
+		Value:    value,
+		MaxAge:   loginCookieMaxAge,
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+		Path:     "/",
+	})
 }
 
-func generateStateOauthCookie(w http.ResponseWriter) string {
-	var expiration = 365 * 24 * 60 * 60
+// clearLoginCookie removes a login cookie once the callback has used it
+func clearLoginCookie(w http.ResponseWriter, name string) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     name,
+		Value:    "",
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+		Path:     "/",
+	})
+}
 
+func generateStateOauthCookie(w http.ResponseWriter) (string, error) {
 	b := make([]byte, 16)
-	rand.Read(b)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
 	state := base64.URLEncoding.EncodeToString(b)
-	cookie := http.Cookie{Name: "oauthstate", Value: state, MaxAge: expiration}
-	http.SetCookie(w, &cookie)
+	setLoginCookie(w, "oauthstate", state)
 
-	return state
+	return state, nil
 }
 
-func HandleLoginDev(w http.ResponseWriter, r *http.Request) {
-	db := persistence.ConnectGORM()
-	email := r.URL.Query().Get("email")
-
-	user := &persistence.User{}
-
-	result := db.Where(persistence.User{Email: email}).Attrs(persistence.User{
-		ID:   uuid.New().String(), // Only set ID if creating a new record
-		Name: "simon",
-	}).FirstOrCreate(user)
-
-	if result.Error != nil {
-		w.Write([]byte(result.Error.Error()))
-		return
+// allowedRedirect reports whether the frontend URL is a path on this server, like the
+// playground at "/", or on one of the ALLOWED_REDIRECT_ORIGINS
+func allowedRedirect(redirectURL string) bool {
+	// Browsers drop tabs and newlines from URLs, /\t/evil.example.com would leave the server too
+	if strings.ContainsFunc(redirectURL, unicode.IsControl) {
+		return false
+	}
+	if strings.HasPrefix(redirectURL, "/") {
+		// Protocol relative URLs like //evil.example.com would leave the server
+		return !strings.HasPrefix(redirectURL, "//") && !strings.HasPrefix(redirectURL, "/\\")
 	}
-	token, err := SignToken(user)
 
-	if err != nil {
-		w.Write([]byte(err.Error()))
-		return
+	parsed, err := url.Parse(redirectURL)
+	if err != nil || parsed.Scheme == "" || parsed.Host == "" || parsed.User != nil {
+		return false
 	}
+	return AllowedOrigin(parsed.Scheme + "://" + parsed.Host)
+}
 
-	w.Write([]byte(token))
-	return
+// AllowedOrigin reports whether the origin is one of our frontends, listed in the comma
+// separated ALLOWED_REDIRECT_ORIGINS, e.g. "https://burger.example.com,http://localhost:3000"
+func AllowedOrigin(origin string) bool {
+	for _, allowed := range strings.Split(os.Getenv("ALLOWED_REDIRECT_ORIGINS"), ",") {
+		allowed = strings.TrimSuffix(strings.TrimSpace(allowed), "/")
+		if allowed != "" && allowed == origin {
+			return true
+		}
+	}
+	return false
 }
 
 func HandleLogin(w http.ResponseWriter, r *http.Request) {
-	state := generateStateOauthCookie(w)
-	// Extract the redirect_url from query parameters
-	redirectURL := r.URL.Query().Get("redirect_url")
-
-	// Validate redirectURL here if necessary
-
-	// Store redirect_url in a secure, http-only cookie
-	http.SetCookie(w, &http.Cookie{
-		Name:     "oauth_redirect_url",
-		Value:    url.QueryEscape(redirectURL), // Ensure the URL is safely encoded
-		MaxAge:   300,                          // 5 minutes or adjust to your flow's timing requirements
-		HttpOnly: true,                         // Make the cookie inaccessible to JavaScript
-		Secure:   true,                         // Ensure cookie is sent over HTTPS only
-		Path:     "/",                          // Scope of the cookie
-		// Set other cookie attributes as necessary, such as SameSite
-	})
-
-	authCodeURL := oauth2Config.AuthCodeURL(state)
-	http.Redirect(w, r, authCodeURL, http.StatusTemporaryRedirect)
-}
-func HandleCallback(w http.ResponseWriter, r *http.Request) {
-	// Retrieve the redirect_url from the cookie
-	cookie, err := r.Cookie("oauth_redirect_url")
-	if err != nil {
-		// Handle missing or invalid cookie
-		http.Error(w, "Session error", http.StatusBadRequest)
-		return
-	}
-	// Decode the URL value from the cookie
-	redirectURL, err := url.QueryUnescape(cookie.Value)
+	// Pick the identity provider, e.g. /login?provider=google
+	providerName, provider, err := providerByName(r.URL.Query().Get("provider"))
 	if err != nil {
-		// Handle error
-		http.Error(w, "Invalid session data", http.StatusBadRequest)
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
-	// Read the oauthState from Cookie
-	oauthState, _ := r.Cookie("oauthstate")
 
-	if r.FormValue("state") != oauthState.Value {
-		http.Error(w, "Invalid state parameter", http.StatusBadRequest)
+	// Extract the redirect_url from query parameters, only our own frontends may receive logins
+	redirectURL := r.URL.Query().Get("redirect_url")
+	if !allowedRedirect(redirectURL) {
+		http.Error(w, "Invalid redirect_url", http.StatusBadRequest)
 		return
 	}
 
-	code := r.FormValue("code")
-	token, err := oauth2Config.Exchange(context.Background(), code)
+	state, err := generateStateOauthCookie(w)
 	if err != nil {
-		http.Error(w, "Code exchange failed: "+err.Error(), http.StatusInternalServerError)
+		http.Error(w, "Failed to generate state", http.StatusInternalServerError)
 		return
 	}
 
-	user, error2 := getUserInfo(token)
-	if error2 != nil {
-		http.Error(w, "Failed to get user info: "+error2.Error(), http.StatusInternalServerError)
+	// With mode=cookie the login ends with a session cookie instead of tokens for the frontend
+	mode := r.URL.Query().Get("mode")
+	if mode != "" && mode != "cookie" && mode != "token" {
+		http.Error(w, "Invalid mode", http.StatusBadRequest)
 		return
 	}
 
-	dbUser, error3 := upsertUser(user)
+	// PKCE binds the authorization code to this browser, so an intercepted code is useless
+	verifier := oauth2.GenerateVerifier()
 
-	if error3 != nil {
-		http.Error(w, "Failed to upsert user: "+error3.Error(), http.StatusInternalServerError)
-		return
-	}
-	// sign a jwt token
-	jwtString, error4 := SignToken(dbUser)
-	if error4 != nil {
-		http.Error(w, "Failed to sign token: "+error4.Error(), http.StatusInternalServerError)
-		return
+	setLoginCookie(w, "oauth_redirect_url", url.QueryEscape(redirectURL))
+	setLoginCookie(w, "oauth_provider", providerName)
+	setLoginCookie(w, "oauth_pkce_verifier", verifier)
+	if mode == "cookie" {
+		setLoginCookie(w, "oauth_mode", mode)
 	}
-	// Construct the final redirect URL with the token
-	finalRedirectURL := fmt.Sprintf("%s?token=%s", redirectURL, url.QueryEscape(jwtString))
 
-	// Redirect the user to the frontend with the token
-	http.Redirect(w, r, finalRedirectURL, http.StatusFound)
+	// login_hint is passed on to the provider, which lets tests pick a mock identity provider user
+	authCodeURL := provider.AuthCodeURL(state, verifier, r.URL.Query().Get("login_hint"))
+	http.Redirect(w, r, authCodeURL, http.StatusTemporaryRedirect)
 }
 
-func SignToken(user *persistence.User) (string, error) {
-	// Define the token claims
-	claims := jwt.MapClaims{
-		"sub": user.ID,                                    // subject, you can use user's email or any unique identifier
-		"exp": time.Now().Add(time.Hour * 24 * 60).Unix(), // token expiration time
+func HandleCallback(db *gorm.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Queries are cancelled along with the request, e.g. when the client goes away
+		db := db.WithContext(r.Context())
+
+		cookieMode := false
+		if cookie, err := r.Cookie("oauth_mode"); err == nil {
+			cookieMode = cookie.Value == "cookie"
+			clearLoginCookie(w, "oauth_mode")
+		}
+
+		cookies := map[string]string{}
+		for _, name := range []string{"oauth_redirect_url", "oauth_provider", "oauthstate", "oauth_pkce_verifier"} {
+			cookie, err := r.Cookie(name)
+			if err != nil || cookie.Value == "" {
+				// Handle missing cookie, e.g. when the login took longer than loginCookieMaxAge
+				http.Error(w, "Session error", http.StatusBadRequest)
+				return
+			}
+			cookies[name] = cookie.Value
+			clearLoginCookie(w, name)
+		}
+
+		// Decode the URL value from the cookie, and check it again in case the allowlist changed
+		redirectURL, err := url.QueryUnescape(cookies["oauth_redirect_url"])
+		if err != nil || !allowedRedirect(redirectURL) {
+			http.Error(w, "Invalid session data", http.StatusBadRequest)
+			return
+		}
+
+		state := cookies["oauthstate"]
+		if subtle.ConstantTimeCompare([]byte(r.FormValue("state")), []byte(state)) != 1 {
+			http.Error(w, "Invalid state parameter", http.StatusBadRequest)
+			return
+		}
+
+		providerName, provider, err := providerByName(cookies["oauth_provider"])
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		code := r.FormValue("code")
+		identity, error2 := provider.Exchange(r.Context(), code, state, cookies["oauth_pkce_verifier"])
+		if error2 != nil {
+			http.Error(w, "Login failed: "+error2.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		dbUser, error3 := upsertUser(db, providerName, identity)
+
+		if error3 != nil {
+			http.Error(w, "Failed to upsert user: "+error3.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		if dbUser.DeactivatedAt != nil {
+			http.Error(w, ErrDeactivated.Error(), http.StatusForbidden)
+			return
+		}
+
+		// Roles and teams follow the directory, so they are synced on every login
+		if err := syncGroups(db, providerName, dbUser, identity.Groups); err != nil {
+			http.Error(w, "Failed to sync groups: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		completeLogin(w, r, db, dbUser, redirectURL, cookieMode)
 	}
-
-	// Create a new token object, specifying signing method and the claims
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-
-	// Sign and get the complete encoded token as a string using the secret
-	tokenString, err := token.SignedString([]byte(os.Getenv("JWT_SECRET"))) // Use your secret key here
-	return tokenString, err
 }
 
-func upsertUser(user *User) (*persistence.User, error) {
-	// Insert or update the user in the database
-	gormDB := persistence.ConnectGORM()
+// completeLogin hands the logged in user to the frontend at redirectURL, either with a session
+// cookie or with a login code in the URL
+func completeLogin(w http.ResponseWriter, r *http.Request, db *gorm.DB, user *persistence.User, redirectURL string, cookieMode bool) {
+	if cookieMode {
+		secret, err := IssueCookieSession(db, user)
+		if err != nil {
+			http.Error(w, "Failed to create session: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		setSessionCookie(w, secret)
+		http.Redirect(w, r, redirectURL, http.StatusFound)
+		return
+	}
 
-	dbUser := &persistence.User{
-		Email: user.Mail, // Use the email as the unique identifier for upsert
+	// Tokens never travel in the URL. The frontend gets a short-lived one-time code instead,
+	// which it exchanges for the tokens at /auth/token
+	loginCode, err := CreateLoginCode(db, user)
+	if err != nil {
+		http.Error(w, "Failed to create login code: "+err.Error(), http.StatusInternalServerError)
+		return
 	}
-	result := gormDB.Where(persistence.User{Email: user.Mail}).Attrs(persistence.User{
-		ID:   uuid.New().String(), // Only set ID if creating a new record
-		Name: user.DisplayName,
-	}).FirstOrCreate(dbUser)
 
-	return dbUser, result.Error
-}
+	finalRedirectURL, _ := url.Parse(redirectURL)
+	query := finalRedirectURL.Query()
+	query.Set("login_code", loginCode)
+	finalRedirectURL.RawQuery = query.Encode()
 
-// User struct to hold the user information from Microsoft Graph
-type User struct {
-	DisplayName       string `json:"displayName"`
-	Mail              string `json:"mail"`
-	UserPrincipalName string `json:"userPrincipalName"` // Often used as the user's email
+	// Redirect the user to the frontend with the code
+	http.Redirect(w, r, finalRedirectURL.String(), http.StatusFound)
 }
 
-func getUserInfo(token *oauth2.Token) (*User, error) {
-	client := oauth2Config.Client(context.Background(), token)
-	resp, err := client.Get("https://graph.microsoft.com/v1.0/me")
-	if err != nil {
-		return nil, fmt.Errorf("request to Graph API failed: %v", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("Graph API returned non-200 status: %d", resp.StatusCode)
+// upsertUser returns the user linked to the provider account, creating it on first login.
+// Users are matched on the provider's subject, the email is only kept up to date. A first login
+// is linked by email only to a user an admin or SCIM provisioned and nobody has logged in as yet,
+// and only when the provider verified the email. Any other user could have chosen the email
+// themselves, so linking to them would let anyone registering it log in as them.
+func upsertUser(gormDB *gorm.DB, providerName string, identity *Identity) (*persistence.User, error) {
+	if identity.Subject == "" {
+		return nil, errors.New("identity provider did not report a subject")
 	}
 
-	var user User
-	if err := json.NewDecoder(resp.Body).Decode(&user); err != nil {
-		return nil, fmt.Errorf("decoding response body failed: %v", err)
+	dbUser := &persistence.User{}
+	err := gormDB.Transaction(func(tx *gorm.DB) error {
+		link := &persistence.UserIdentity{}
+		res := tx.Where("provider = ? AND subject = ?", providerName, identity.Subject).Limit(1).Find(link)
+		if res.Error != nil {
+			return res.Error
+		}
+
+		if res.RowsAffected > 0 {
+			if err := tx.First(dbUser, "id = ?", link.UserId).Error; err != nil {
+				return err
+			}
+			if identity.Email != "" && identity.Email != dbUser.Email {
+				return tx.Model(dbUser).Updates(map[string]interface{}{
+					"email":   identity.Email,
+					"version": gorm.Expr("version + 1"),
+				}).Error
+			}
+			return nil
+		}
+
+		found := int64(0)
+		if identity.Email != "" && identity.EmailVerified {
+			res = tx.Where("LOWER(email) = LOWER(?) AND service_account = ? AND pending_link = ?", identity.Email, false, true).
+				Where("NOT EXISTS (SELECT 1 FROM user_identities WHERE user_identities.user_id = users.id)").
+				Limit(1).Find(dbUser)
+			if res.Error != nil {
+				return res.Error
+			}
+			found = res.RowsAffected
+		}
+
+		if found > 0 {
+			if err := tx.Model(dbUser).Updates(map[string]interface{}{
+				"pending_link": false,
+				"version":      gorm.Expr("version + 1"),
+			}).Error; err != nil {
+				return err
+			}
+		}
+
+		if found == 0 {
+			dbUser = &persistence.User{
+				ID:    uuid.New().String(),
+				Name:  identity.Name,
+				Email: identity.Email,
+			}
+			if err := tx.Create(dbUser).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Create(&persistence.UserIdentity{
+			Provider: providerName,
+			Subject:  identity.Subject,
+			UserId:   dbUser.ID,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return &user, nil
+	return dbUser, nil
 }
@@ -0,0 +1,42 @@
+package auth
+
+import "testing"
+
+func TestAllowedRedirect(t *testing.T) {
+	t.Setenv("ALLOWED_REDIRECT_ORIGINS", "https://burger.example.com, http://localhost:3000/")
+
+	tests := []struct {
+		redirectURL string
+		allowed     bool
+	}{
+		{"/", true},
+		{"/orders?day=2024-03-21", true},
+		{"https://burger.example.com", true},
+		{"https://burger.example.com/callback#done", true},
+		{"http://localhost:3000/login", true},
+		{"", false},
+		{"//evil.example.com", false},
+		{"/\\evil.example.com", false},
+		{"/\t/evil.example.com", false},
+		{"/\n/evil.example.com", false},
+		{"http://burger.example.com", false},
+		{"https://burger.example.com.evil.example.com", false},
+		{"https://burger.example.com@evil.example.com", false},
+		{"https://user@burger.example.com", false},
+		{"https://evil.example.com/https://burger.example.com", false},
+		{"javascript:alert(1)", false},
+		{"burger.example.com", false},
+	}
+	for _, test := range tests {
+		if got := allowedRedirect(test.redirectURL); got != test.allowed {
+			t.Errorf("allowedRedirect(%q) = %v, want %v", test.redirectURL, got, test.allowed)
+		}
+	}
+}
+
+func TestAllowedOrigin(t *testing.T) {
+	t.Setenv("ALLOWED_REDIRECT_ORIGINS", "")
+	if AllowedOrigin("") || AllowedOrigin("https://burger.example.com") {
+		t.Error("allowed an origin without ALLOWED_REDIRECT_ORIGINS")
+	}
+}
@@ -0,0 +1,323 @@
+// Package mockidp is a local stand-in for an OpenID Connect identity provider. The login flow
+// in the auth package is pointed at it like at any other provider, so logins can be tested
+// end to end without network access or real accounts.
+//
+// Users are picked on a plain HTML page, or directly with the standard login_hint parameter
+// (matching the subject or email) which makes the flow scriptable.
+package mockidp
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"graphql-go/auth"
+	"html/template"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+const (
+	keyID        = "mock-idp"
+	codeLifetime = time.Minute
+	idTokenTTL   = time.Hour
+)
+
+// User is an account of the mock identity provider
+type User struct {
+	Subject string   `json:"sub"`
+	Name    string   `json:"name"`
+	Email   string   `json:"email"`
+	Groups  []string `json:"groups"`
+	// Claims are added to the ID token as is, e.g. to mimic provider specific claims
+	Claims map[string]interface{} `json:"claims"`
+}
+
+// Config configures the mock identity provider
+type Config struct {
+	// Issuer is the URL the provider is reachable at, e.g. http://localhost:9000
+	Issuer string
+	// ClientID is the only client allowed to log in, any client is allowed when empty
+	ClientID string
+	Users    []User
+}
+
+// authorization is a code handed out by the authorize endpoint, waiting to be exchanged
+type authorization struct {
+	user          User
+	clientID      string
+	redirectURI   string
+	nonce         string
+	codeChallenge string
+	expiresAt     time.Time
+}
+
+// Server is the mock identity provider
+type Server struct {
+	config Config
+	key    *ecdsa.PrivateKey
+
+	codesMutex sync.Mutex
+	codes      map[string]*authorization
+}
+
+// New creates a mock identity provider with a freshly generated signing key
+func New(config Config) (*Server, error) {
+	if config.Issuer == "" {
+		return nil, fmt.Errorf("issuer is required")
+	}
+	config.Issuer = strings.TrimSuffix(config.Issuer, "/")
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Server{
+		config: config,
+		key:    key,
+		codes:  map[string]*authorization{},
+	}, nil
+}
+
+// LoadUsers reads the users from a JSON file holding an array of User
+func LoadUsers(path string) ([]User, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var users []User
+	if err := json.Unmarshal(data, &users); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return users, nil
+}
+
+// Handler serves the discovery document and the authorize, token and JWKS endpoints
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", s.handleDiscovery)
+	mux.HandleFunc("/authorize", s.handleAuthorize)
+	mux.HandleFunc("/token", s.handleToken)
+	mux.HandleFunc("/jwks", s.handleJWKS)
+	return mux
+}
+
+func (s *Server) handleDiscovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"issuer":                                s.config.Issuer,
+		"authorization_endpoint":                s.config.Issuer + "/authorize",
+		"token_endpoint":                        s.config.Issuer + "/token",
+		"jwks_uri":                              s.config.Issuer + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"ES256"},
+		"code_challenge_methods_supported":      []string{"S256"},
+		"claims_supported":                      []string{"sub", "name", "email", "email_verified", "groups"},
+	})
+}
+
+var pickerTemplate = template.Must(template.New("picker").Parse(`<!DOCTYPE html>
+<html>
+<head><title>Mock identity provider</title></head>
+<body>
+<h1>Log in as</h1>
+<ul>
+{{range .}}<li><a href="{{.URL}}">{{.User.Name}} &lt;{{.User.Email}}&gt;</a>{{if .User.Groups}} ({{range $i, $g := .User.Groups}}{{if $i}}, {{end}}{{$g}}{{end}}){{end}}</li>
+{{end}}</ul>
+</body>
+</html>
+`))
+
+func (s *Server) handleAuthorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get("response_type") != "code" {
+		http.Error(w, "unsupported response_type", http.StatusBadRequest)
+		return
+	}
+	clientID := query.Get("client_id")
+	if s.config.ClientID != "" && clientID != s.config.ClientID {
+		http.Error(w, "unknown client_id", http.StatusBadRequest)
+		return
+	}
+	redirectURI, err := url.Parse(query.Get("redirect_uri"))
+	if err != nil || redirectURI.Scheme == "" {
+		http.Error(w, "invalid redirect_uri", http.StatusBadRequest)
+		return
+	}
+	if query.Get("code_challenge") != "" && query.Get("code_challenge_method") != "S256" {
+		http.Error(w, "unsupported code_challenge_method", http.StatusBadRequest)
+		return
+	}
+
+	hint := query.Get("login_hint")
+	if hint == "" {
+		s.renderPicker(w, r)
+		return
+	}
+
+	user, ok := s.findUser(hint)
+	if !ok {
+		http.Error(w, "unknown user: "+hint, http.StatusNotFound)
+		return
+	}
+
+	code := uuid.New().String()
+	s.codesMutex.Lock()
+	s.codes[code] = &authorization{
+		user:          user,
+		clientID:      clientID,
+		redirectURI:   redirectURI.String(),
+		nonce:         query.Get("nonce"),
+		codeChallenge: query.Get("code_challenge"),
+		expiresAt:     time.Now().Add(codeLifetime),
+	}
+	s.codesMutex.Unlock()
+
+	callback := redirectURI.Query()
+	callback.Set("code", code)
+	callback.Set("state", query.Get("state"))
+	redirectURI.RawQuery = callback.Encode()
+	http.Redirect(w, r, redirectURI.String(), http.StatusFound)
+}
+
+// renderPicker lists the users, each linking back to the authorize endpoint with a login_hint
+func (s *Server) renderPicker(w http.ResponseWriter, r *http.Request) {
+	type entry struct {
+		User User
+		URL  string
+	}
+
+	entries := make([]entry, len(s.config.Users))
+	for i, user := range s.config.Users {
+		query := r.URL.Query()
+		query.Set("login_hint", user.Subject)
+		entries[i] = entry{User: user, URL: "/authorize?" + query.Encode()}
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	pickerTemplate.Execute(w, entries)
+}
+
+func (s *Server) findUser(hint string) (User, bool) {
+	for _, user := range s.config.Users {
+		if user.Subject == hint || strings.EqualFold(user.Email, hint) {
+			return user, true
+		}
+	}
+	return User{}, false
+}
+
+func (s *Server) handleToken(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		tokenError(w, "invalid_request")
+		return
+	}
+	if r.PostForm.Get("grant_type") != "authorization_code" {
+		tokenError(w, "unsupported_grant_type")
+		return
+	}
+
+	// Codes are single use, so take it out before checking anything else
+	code := r.PostForm.Get("code")
+	s.codesMutex.Lock()
+	auth, ok := s.codes[code]
+	delete(s.codes, code)
+	s.codesMutex.Unlock()
+
+	if !ok || time.Now().After(auth.expiresAt) || r.PostForm.Get("redirect_uri") != auth.redirectURI {
+		tokenError(w, "invalid_grant")
+		return
+	}
+
+	clientID, _, ok := r.BasicAuth()
+	if !ok {
+		clientID = r.PostForm.Get("client_id")
+	}
+	if clientID != auth.clientID {
+		tokenError(w, "invalid_client")
+		return
+	}
+
+	if auth.codeChallenge != "" {
+		sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+		if base64.RawURLEncoding.EncodeToString(sum[:]) != auth.codeChallenge {
+			tokenError(w, "invalid_grant")
+			return
+		}
+	}
+
+	idToken, err := s.signIDToken(auth)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": uuid.New().String(),
+		"token_type":   "Bearer",
+		"expires_in":   int(idTokenTTL.Seconds()),
+		"id_token":     idToken,
+	})
+}
+
+func (s *Server) signIDToken(auth *authorization) (string, error) {
+	now := time.Now()
+	claims := jwt.MapClaims{}
+	for name, value := range auth.user.Claims {
+		claims[name] = value
+	}
+	claims["iss"] = s.config.Issuer
+	claims["sub"] = auth.user.Subject
+	claims["aud"] = auth.clientID
+	claims["iat"] = now.Unix()
+	claims["exp"] = now.Add(idTokenTTL).Unix()
+	claims["name"] = auth.user.Name
+	claims["email"] = auth.user.Email
+	claims["email_verified"] = true
+	if auth.nonce != "" {
+		claims["nonce"] = auth.nonce
+	}
+	if len(auth.user.Groups) > 0 {
+		claims["groups"] = auth.user.Groups
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, claims)
+	token.Header["kid"] = keyID
+	return token.SignedString(s.key)
+}
+
+func (s *Server) handleJWKS(w http.ResponseWriter, r *http.Request) {
+	jwk, err := auth.NewJWK(keyID, "ES256", &s.key.PublicKey)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, http.StatusOK, auth.JWKS{Keys: []auth.JWK{*jwk}})
+}
+
+func tokenError(w http.ResponseWriter, code string) {
+	writeJSON(w, http.StatusBadRequest, map[string]string{"error": code})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,208 @@
+package mockidp
+
+import (
+	"context"
+	"encoding/json"
+	"graphql-go/auth"
+	"graphql-go/persistence"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+// newTestDB returns a migrated in-memory SQLite database with signing keys configured
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	t.Setenv("DB_URL", "sqlite::memory:")
+	db, err := persistence.ConnectGORM(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+
+	if err := persistence.EnsureMigrated(db, true); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	if err := auth.ConfigureSigningKeys(ctx, db); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+// testLogin runs the app's login routes against the mock identity provider, both offline
+type testLogin struct {
+	app    *httptest.Server
+	idp    *httptest.Server
+	client *http.Client
+}
+
+func newTestLogin(t *testing.T, db *gorm.DB, users []User) *testLogin {
+	t.Helper()
+
+	// The issuer has to be known before the provider is created, so the handler is set after
+	var idp *Server
+	idpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		idp.Handler().ServeHTTP(w, r)
+	}))
+	t.Cleanup(idpServer.Close)
+	idp, err := New(Config{Issuer: idpServer.URL, ClientID: "burger-app", Users: users})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/login", auth.HandleLogin)
+	mux.HandleFunc("/auth/callback", auth.HandleCallback(db))
+	mux.HandleFunc("/auth/token", auth.HandleTokenExchange(db))
+	mux.Handle("/me", auth.Middleware(db)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(auth.ForContext(r.Context()))
+	})))
+	// Stands in for the frontend receiving the login code
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {})
+	// The login cookies are Secure, so the app is served over TLS
+	app := httptest.NewTLSServer(mux)
+	t.Cleanup(app.Close)
+
+	t.Setenv("OIDC_PROVIDERS", "mock")
+	t.Setenv("OIDC_MOCK_ISSUER", idpServer.URL)
+	t.Setenv("OIDC_MOCK_CLIENT_ID", "burger-app")
+	t.Setenv("OIDC_MOCK_REDIRECT_URL", app.URL+"/auth/callback")
+	if err := auth.ConfigureProviders(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := app.Client()
+	client.Jar = jar
+	return &testLogin{app: app, idp: idpServer, client: client}
+}
+
+// login follows the redirects from /login through the mock provider and back to the frontend,
+// and exchanges the login code for an access token
+func (l *testLogin) login(t *testing.T, hint string) string {
+	t.Helper()
+
+	resp, err := l.client.Get(l.app.URL + "/login?redirect_url=/&login_hint=" + hint)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("login ended at %s with status %d", resp.Request.URL, resp.StatusCode)
+	}
+	code := resp.Request.URL.Query().Get("login_code")
+	if resp.Request.URL.Host != l.app.Listener.Addr().String() || code == "" {
+		t.Fatalf("login ended at %s, want the frontend with a login code", resp.Request.URL)
+	}
+
+	resp, err = l.client.Post(l.app.URL+"/auth/token", "application/json", strings.NewReader(`{"code":"`+code+`"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var tokens struct {
+		AccessToken  string `json:"accessToken"`
+		RefreshToken string `json:"refreshToken"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tokens); err != nil || tokens.AccessToken == "" {
+		t.Fatalf("token exchange failed with status %d: %v", resp.StatusCode, err)
+	}
+	return tokens.AccessToken
+}
+
+func (l *testLogin) me(t *testing.T, accessToken string) *persistence.User {
+	t.Helper()
+
+	req, _ := http.NewRequest(http.MethodGet, l.app.URL+"/me", nil)
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	resp, err := l.client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("access token was rejected with status %d", resp.StatusCode)
+	}
+	var user persistence.User
+	if err := json.NewDecoder(resp.Body).Decode(&user); err != nil {
+		t.Fatal(err)
+	}
+	return &user
+}
+
+func TestLogin(t *testing.T) {
+	db := newTestDB(t)
+	l := newTestLogin(t, db, []User{
+		{Subject: "alice", Name: "Alice", Email: "alice@example.com"},
+		{Subject: "bob", Name: "Bob", Email: "bob@example.com"},
+	})
+
+	alice := l.me(t, l.login(t, "alice"))
+	if alice.Name != "Alice" || alice.Email != "alice@example.com" {
+		t.Errorf("logged in as %s <%s>, want Alice", alice.Name, alice.Email)
+	}
+
+	var identity persistence.UserIdentity
+	if err := db.First(&identity, "provider = ? AND subject = ?", "mock", "alice").Error; err != nil {
+		t.Fatalf("no identity for the login: %v", err)
+	}
+	if identity.UserId != alice.ID {
+		t.Errorf("identity belongs to %s, want %s", identity.UserId, alice.ID)
+	}
+
+	// Logging in again, this time by email, finds the same user
+	if again := l.me(t, l.login(t, "ALICE@example.com")); again.ID != alice.ID {
+		t.Errorf("second login as %s, want %s", again.ID, alice.ID)
+	}
+	if bob := l.me(t, l.login(t, "bob")); bob.ID == alice.ID || bob.Email != "bob@example.com" {
+		t.Errorf("logged in as %s <%s>, want Bob", bob.ID, bob.Email)
+	}
+}
+
+func TestLoginRejected(t *testing.T) {
+	l := newTestLogin(t, newTestDB(t), []User{{Subject: "alice", Name: "Alice", Email: "alice@example.com"}})
+
+	resp, err := l.client.Get(l.app.URL + "/login?redirect_url=/&login_hint=mallory")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("unknown user got status %d, want 404 from the provider", resp.StatusCode)
+	}
+
+	// A callback the app did not start is refused, its login cookies are missing
+	l.client.Jar, _ = cookiejar.New(nil)
+	resp, err = l.client.Get(l.app.URL + "/auth/callback?code=stolen&state=guessed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("callback without login cookies got status %d, want 400", resp.StatusCode)
+	}
+
+	resp, err = l.client.Post(l.app.URL+"/auth/token", "application/json", strings.NewReader(`{"code":"made-up"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		t.Error("exchanged a made up login code")
+	}
+}
@@ -0,0 +1,225 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/oauth2"
+)
+
+// OIDCConfig configures a generic OpenID Connect provider
+type OIDCConfig struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	// Scopes defaults to openid, profile and email
+	Scopes []string
+}
+
+// oidcDiscovery is the subset of the discovery document at /.well-known/openid-configuration we use
+type oidcDiscovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// oidcProvider logs users in with any OpenID Connect compliant provider, reading the identity
+// from the verified ID token
+type oidcProvider struct {
+	issuer  string
+	jwksURI string
+	config  *oauth2.Config
+
+	keysMutex sync.Mutex
+	keys      map[string]interface{}
+}
+
+// NewOIDCProvider fetches the issuer's discovery document and returns a provider for it
+func NewOIDCProvider(ctx context.Context, cfg OIDCConfig) (Provider, error) {
+	if cfg.Issuer == "" || cfg.ClientID == "" {
+		return nil, errors.New("issuer and client id are required")
+	}
+
+	discovery, err := discover(ctx, cfg.Issuer)
+	if err != nil {
+		return nil, err
+	}
+
+	scopes := cfg.Scopes
+	if len(scopes) == 0 {
+		scopes = []string{"openid", "profile", "email"}
+	}
+
+	return &oidcProvider{
+		issuer:  discovery.Issuer,
+		jwksURI: discovery.JWKSURI,
+		config: &oauth2.Config{
+			ClientID:     cfg.ClientID,
+			ClientSecret: cfg.ClientSecret,
+			RedirectURL:  cfg.RedirectURL,
+			Scopes:       scopes,
+			Endpoint: oauth2.Endpoint{
+				AuthURL:  discovery.AuthorizationEndpoint,
+				TokenURL: discovery.TokenEndpoint,
+			},
+		},
+		keys: map[string]interface{}{},
+	}, nil
+}
+
+func discover(ctx context.Context, issuer string) (*oidcDiscovery, error) {
+	wellKnown := strings.TrimSuffix(issuer, "/") + "/.well-known/openid-configuration"
+	var discovery oidcDiscovery
+	if err := getJSON(ctx, wellKnown, &discovery); err != nil {
+		return nil, fmt.Errorf("fetching discovery document failed: %w", err)
+	}
+
+	// The issuer in the document must match the one we were configured with (OIDC Discovery 4.3)
+	if strings.TrimSuffix(discovery.Issuer, "/") != strings.TrimSuffix(issuer, "/") {
+		return nil, fmt.Errorf("discovery document issuer %s does not match %s", discovery.Issuer, issuer)
+	}
+	if discovery.AuthorizationEndpoint == "" || discovery.TokenEndpoint == "" || discovery.JWKSURI == "" {
+		return nil, errors.New("discovery document is missing required endpoints")
+	}
+
+	return &discovery, nil
+}
+
+// AuthCodeURL uses the state as the nonce too, since it is already random and bound to the browser
+func (p *oidcProvider) AuthCodeURL(state string, verifier string, loginHint string) string {
+	opts := []oauth2.AuthCodeOption{oauth2.SetAuthURLParam("nonce", state), oauth2.S256ChallengeOption(verifier)}
+	if loginHint != "" {
+		opts = append(opts, oauth2.SetAuthURLParam("login_hint", loginHint))
+	}
+	return p.config.AuthCodeURL(state, opts...)
+}
+
+func (p *oidcProvider) Exchange(ctx context.Context, code string, state string, verifier string) (*Identity, error) {
+	token, err := p.config.Exchange(ctx, code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return nil, fmt.Errorf("code exchange failed: %w", err)
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok || rawIDToken == "" {
+		return nil, errors.New("token response did not contain an id_token")
+	}
+
+	claims, err := p.verifyIDToken(ctx, rawIDToken)
+	if err != nil {
+		return nil, fmt.Errorf("invalid id_token: %w", err)
+	}
+
+	if claims.Nonce != state {
+		return nil, errors.New("invalid id_token: nonce mismatch")
+	}
+	if claims.Email != "" && claims.EmailVerified != nil && !*claims.EmailVerified {
+		return nil, errors.New("email address is not verified")
+	}
+
+	name := claims.Name
+	if name == "" {
+		name = claims.PreferredUsername
+	}
+
+	return &Identity{
+		Subject: claims.Subject,
+		Name:    name,
+		Email:   claims.Email,
+		// Providers that leave out email_verified make no promise about the email
+		EmailVerified: claims.Email != "" && claims.EmailVerified != nil && *claims.EmailVerified,
+		Groups:        claims.Groups,
+	}, nil
+}
+
+// idTokenClaims are the standard OIDC claims we read from the ID token
+type idTokenClaims struct {
+	jwt.RegisteredClaims
+	Nonce             string `json:"nonce"`
+	Name              string `json:"name"`
+	PreferredUsername string `json:"preferred_username"`
+	Email             string `json:"email"`
+	EmailVerified     *bool  `json:"email_verified"`
+	// Groups is not standardized, but commonly used by providers configured to send it
+	Groups []string `json:"groups"`
+}
+
+func (p *oidcProvider) verifyIDToken(ctx context.Context, rawIDToken string) (*idTokenClaims, error) {
+	claims := &idTokenClaims{}
+	_, err := jwt.ParseWithClaims(rawIDToken, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return p.publicKey(ctx, kid)
+	},
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512"}),
+		jwt.WithIssuer(p.issuer),
+		jwt.WithAudience(p.config.ClientID),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+// publicKey returns the signing key with the given id, refetching the JWKS when the key is
+// unknown so rotations at the provider are picked up
+func (p *oidcProvider) publicKey(ctx context.Context, kid string) (interface{}, error) {
+	p.keysMutex.Lock()
+	defer p.keysMutex.Unlock()
+
+	if key, ok := p.keys[kid]; ok {
+		return key, nil
+	}
+
+	var jwks JWKS
+	if err := getJSON(ctx, p.jwksURI, &jwks); err != nil {
+		return nil, fmt.Errorf("fetching JWKS failed: %w", err)
+	}
+
+	keys := map[string]interface{}{}
+	for _, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.PublicKey()
+		if err != nil {
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+	p.keys = keys
+
+	key, ok := p.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key: %s", kid)
+	}
+	return key, nil
+}
+
+func getJSON(ctx context.Context, url string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned non-200 status: %d", url, resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
@@ -0,0 +1,270 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	testClientID = "burger-app"
+	testNonce    = "state-and-nonce"
+)
+
+// testIssuer is a stand-in OpenID provider. Its token endpoint answers with the claims the test
+// sets, signed with the published key unless sign is replaced.
+type testIssuer struct {
+	server    *httptest.Server
+	key       *ecdsa.PrivateKey
+	kid       string
+	discovery map[string]interface{}
+	claims    jwt.MapClaims
+	sign      func(claims jwt.MapClaims) string
+	tokenForm url.Values
+	jwksHits  int
+}
+
+func newTestIssuer(t *testing.T) *testIssuer {
+	t.Helper()
+
+	issuer := &testIssuer{kid: "key-1"}
+	issuer.key = newTestECKey(t)
+	issuer.sign = func(claims jwt.MapClaims) string {
+		return signTestToken(t, jwt.SigningMethodES256, issuer.key, issuer.kid, claims)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(issuer.discovery)
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		issuer.tokenForm = r.PostForm
+		if r.PostForm.Get("code") != "good-code" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"invalid_grant"}`))
+			return
+		}
+		response := map[string]interface{}{"access_token": "opaque", "token_type": "Bearer"}
+		if issuer.claims != nil {
+			response["id_token"] = issuer.sign(issuer.claims)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		issuer.jwksHits++
+		jwk, err := NewJWK(issuer.kid, "ES256", &issuer.key.PublicKey)
+		if err != nil {
+			t.Error(err)
+		}
+		json.NewEncoder(w).Encode(JWKS{Keys: []JWK{*jwk}})
+	})
+	issuer.server = httptest.NewServer(mux)
+	t.Cleanup(issuer.server.Close)
+
+	base := issuer.server.URL
+	issuer.discovery = map[string]interface{}{
+		"issuer":                 base,
+		"authorization_endpoint": base + "/authorize",
+		"token_endpoint":         base + "/token",
+		"jwks_uri":               base + "/jwks",
+	}
+	issuer.claims = jwt.MapClaims{
+		"iss":            base,
+		"sub":            "subject-1",
+		"aud":            testClientID,
+		"exp":            time.Now().Add(time.Hour).Unix(),
+		"nonce":          testNonce,
+		"name":           "Alice",
+		"email":          "alice@example.com",
+		"email_verified": true,
+		"groups":         []string{"group-1"},
+	}
+	return issuer
+}
+
+func newTestECKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func signTestToken(t *testing.T, method jwt.SigningMethod, key interface{}, kid string, claims jwt.MapClaims) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+func (i *testIssuer) provider(t *testing.T) Provider {
+	t.Helper()
+
+	provider, err := NewOIDCProvider(context.Background(), OIDCConfig{
+		Issuer:       i.server.URL,
+		ClientID:     testClientID,
+		ClientSecret: "secret",
+		RedirectURL:  "https://burger.example.com/auth/callback",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return provider
+}
+
+func TestOIDCDiscovery(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(discovery map[string]interface{})
+	}{
+		{"another issuer", func(d map[string]interface{}) { d["issuer"] = "https://evil.example.com" }},
+		{"no token endpoint", func(d map[string]interface{}) { delete(d, "token_endpoint") }},
+		{"no JWKS", func(d map[string]interface{}) { delete(d, "jwks_uri") }},
+	}
+	for _, test := range tests {
+		issuer := newTestIssuer(t)
+		test.change(issuer.discovery)
+		_, err := NewOIDCProvider(context.Background(), OIDCConfig{Issuer: issuer.server.URL, ClientID: testClientID})
+		if err == nil {
+			t.Errorf("%s: discovery succeeded", test.name)
+		}
+	}
+
+	if _, err := NewOIDCProvider(context.Background(), OIDCConfig{Issuer: newTestIssuer(t).server.URL + "/missing", ClientID: testClientID}); err == nil {
+		t.Error("discovered an issuer without a discovery document")
+	}
+}
+
+func TestOIDCLogin(t *testing.T) {
+	issuer := newTestIssuer(t)
+	provider := issuer.provider(t)
+
+	authURL, err := url.Parse(provider.AuthCodeURL(testNonce, "verifier", "alice@example.com"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	query := authURL.Query()
+	if authURL.Path != "/authorize" || query.Get("client_id") != testClientID || query.Get("state") != testNonce ||
+		query.Get("nonce") != testNonce || query.Get("code_challenge_method") != "S256" || query.Get("login_hint") != "alice@example.com" {
+		t.Errorf("unexpected authorization URL %s", authURL)
+	}
+
+	identity, err := provider.Exchange(context.Background(), "good-code", testNonce, "verifier")
+	if err != nil {
+		t.Fatalf("Exchange failed: %v", err)
+	}
+	if issuer.tokenForm.Get("code_verifier") != "verifier" || issuer.tokenForm.Get("grant_type") != "authorization_code" {
+		t.Errorf("unexpected token request %v", issuer.tokenForm)
+	}
+	want := Identity{Subject: "subject-1", Name: "Alice", Email: "alice@example.com", EmailVerified: true}
+	if identity.Subject != want.Subject || identity.Name != want.Name || identity.Email != want.Email || !identity.EmailVerified {
+		t.Errorf("got %+v, want %+v", identity, want)
+	}
+	if len(identity.Groups) != 1 || identity.Groups[0] != "group-1" {
+		t.Errorf("got groups %v", identity.Groups)
+	}
+
+	// Providers that leave email_verified out make no promise
+	delete(issuer.claims, "email_verified")
+	identity, err = provider.Exchange(context.Background(), "good-code", testNonce, "verifier")
+	if err != nil {
+		t.Fatalf("Exchange failed: %v", err)
+	}
+	if identity.EmailVerified {
+		t.Error("email without email_verified counts as verified")
+	}
+}
+
+func TestOIDCLoginRejected(t *testing.T) {
+	tests := []struct {
+		name   string
+		code   string
+		change func(issuer *testIssuer)
+	}{
+		{"rejected code", "bad-code", func(*testIssuer) {}},
+		{"no ID token", "", func(i *testIssuer) { i.claims = nil }},
+		{"another issuer", "", func(i *testIssuer) { i.claims["iss"] = "https://evil.example.com" }},
+		{"another audience", "", func(i *testIssuer) { i.claims["aud"] = "another-app" }},
+		{"another nonce", "", func(i *testIssuer) { i.claims["nonce"] = "replayed" }},
+		{"no nonce", "", func(i *testIssuer) { delete(i.claims, "nonce") }},
+		{"expired", "", func(i *testIssuer) { i.claims["exp"] = time.Now().Add(-time.Minute).Unix() }},
+		{"no expiry", "", func(i *testIssuer) { delete(i.claims, "exp") }},
+		{"unverified email", "", func(i *testIssuer) { i.claims["email_verified"] = false }},
+		{"signed by another key", "", func(i *testIssuer) {
+			other := newTestECKey(t)
+			i.sign = func(claims jwt.MapClaims) string {
+				return signTestToken(t, jwt.SigningMethodES256, other, i.kid, claims)
+			}
+		}},
+		{"unknown key", "", func(i *testIssuer) {
+			i.sign = func(claims jwt.MapClaims) string {
+				return signTestToken(t, jwt.SigningMethodES256, i.key, "key-2", claims)
+			}
+		}},
+		{"signed with the client secret", "", func(i *testIssuer) {
+			i.sign = func(claims jwt.MapClaims) string {
+				return signTestToken(t, jwt.SigningMethodHS256, []byte("secret"), i.kid, claims)
+			}
+		}},
+		{"unsigned", "", func(i *testIssuer) {
+			i.sign = func(claims jwt.MapClaims) string {
+				return signTestToken(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, i.kid, claims)
+			}
+		}},
+	}
+	for _, test := range tests {
+		issuer := newTestIssuer(t)
+		provider := issuer.provider(t)
+		test.change(issuer)
+
+		code := test.code
+		if code == "" {
+			code = "good-code"
+		}
+		if identity, err := provider.Exchange(context.Background(), code, testNonce, "verifier"); err == nil {
+			t.Errorf("%s: logged in as %+v", test.name, identity)
+		}
+	}
+}
+
+func TestOIDCKeyRotation(t *testing.T) {
+	issuer := newTestIssuer(t)
+	provider := issuer.provider(t)
+
+	for i := 0; i < 2; i++ {
+		if _, err := provider.Exchange(context.Background(), "good-code", testNonce, "verifier"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if issuer.jwksHits != 1 {
+		t.Errorf("fetched the JWKS %d times for a known key, want once", issuer.jwksHits)
+	}
+
+	// The provider rotated, tokens signed with the new key are accepted after a refetch
+	issuer.key, issuer.kid = newTestECKey(t), "key-2"
+	if _, err := provider.Exchange(context.Background(), "good-code", testNonce, "verifier"); err != nil {
+		t.Fatalf("rejected a token of the rotated key: %v", err)
+	}
+	if issuer.jwksHits != 2 {
+		t.Errorf("fetched the JWKS %d times, want a refetch for the new key", issuer.jwksHits)
+	}
+}
@@ -0,0 +1,114 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Identity is the profile an identity provider reports for the user that logged in
+type Identity struct {
+	Subject string
+	Name    string
+	Email   string
+	// EmailVerified is set when the provider vouches for the email, only then is a first login
+	// linked by email to a provisioned user
+	EmailVerified bool
+	// Groups are the directory groups the user is a member of, by id
+	Groups []string
+}
+
+// Provider is an identity provider the OAuth login flow can redirect users to
+type Provider interface {
+	// AuthCodeURL returns the URL of the provider's consent page for the given state and
+	// PKCE code verifier. The optional login hint preselects the account to log in with.
+	AuthCodeURL(state string, verifier string, loginHint string) string
+	// Exchange trades the authorization code for the identity of the user that logged in.
+	// The state and verifier are the values passed to AuthCodeURL, the state has already been verified.
+	Exchange(ctx context.Context, code string, state string, verifier string) (*Identity, error)
+}
+
+// providers holds the identity providers configured at startup, keyed by name
+var providers = map[string]Provider{}
+
+// defaultProvider is used when a login does not name a provider
+var defaultProvider string
+
+// ConfigureProviders sets up the identity providers from the environment.
+//
+// Azure AD is configured when AZURE_CLIENT_ID is set. Generic OpenID Connect providers are
+// listed by name in OIDC_PROVIDERS, e.g. "google", and read OIDC_GOOGLE_ISSUER,
+// OIDC_GOOGLE_CLIENT_ID, OIDC_GOOGLE_CLIENT_SECRET, OIDC_GOOGLE_REDIRECT_URL and the
+// optional space separated OIDC_GOOGLE_SCOPES. AUTH_DEFAULT_PROVIDER picks the provider
+// used when /login is called without ?provider=, otherwise the first configured one is used.
+// GROUP_ROLES_<PROVIDER> and GROUP_TEAMS_<PROVIDER> map the groups a provider reports at login
+// to roles and teams.
+func ConfigureProviders(ctx context.Context) error {
+	configured := map[string]Provider{}
+	var order []string
+
+	if os.Getenv("AZURE_CLIENT_ID") != "" {
+		configured["azure"] = newAzureProvider()
+		order = append(order, "azure")
+	}
+
+	for _, name := range strings.Split(os.Getenv("OIDC_PROVIDERS"), ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		prefix := "OIDC_" + strings.ToUpper(name) + "_"
+		provider, err := NewOIDCProvider(ctx, OIDCConfig{
+			Issuer:       os.Getenv(prefix + "ISSUER"),
+			ClientID:     os.Getenv(prefix + "CLIENT_ID"),
+			ClientSecret: os.Getenv(prefix + "CLIENT_SECRET"),
+			RedirectURL:  os.Getenv(prefix + "REDIRECT_URL"),
+			Scopes:       strings.Fields(os.Getenv(prefix + "SCOPES")),
+		})
+		if err != nil {
+			return fmt.Errorf("configuring OIDC provider %s: %w", name, err)
+		}
+		configured[name] = provider
+		order = append(order, name)
+	}
+
+	if err := configureGroupMapping(order); err != nil {
+		return err
+	}
+
+	// Running without providers is allowed, e.g. for working on the schema only
+	if len(configured) == 0 {
+		providers = configured
+		defaultProvider = ""
+		return nil
+	}
+
+	defaultName := os.Getenv("AUTH_DEFAULT_PROVIDER")
+	if defaultName == "" {
+		defaultName = order[0]
+	}
+	if _, ok := configured[defaultName]; !ok {
+		return fmt.Errorf("default identity provider %s is not configured", defaultName)
+	}
+
+	providers = configured
+	defaultProvider = defaultName
+	return nil
+}
+
+// providerByName returns the named provider, or the default one when name is empty
+func providerByName(name string) (string, Provider, error) {
+	if len(providers) == 0 {
+		return "", nil, fmt.Errorf("no identity providers configured")
+	}
+	if name == "" {
+		name = defaultProvider
+	}
+	provider, ok := providers[name]
+	if !ok {
+		return "", nil, fmt.Errorf("unknown identity provider: %s", name)
+	}
+	return name, provider, nil
+}
@@ -0,0 +1,44 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"graphql-go/graph/model"
+	"graphql-go/persistence"
+)
+
+// ErrUnauthenticated is returned when a resolver requires a user but none is in context
+var ErrUnauthenticated = errors.New("unauthenticated: no user in context")
+
+// roleRank orders roles from least to most privileged, so a higher role implies the lower ones
+var roleRank = map[model.Role]int{
+	model.RoleGuest:  0,
+	model.RoleMember: 1,
+	model.RoleHost:   2,
+	model.RoleAdmin:  3,
+}
+
+// HasRole reports whether the user holds the given role or a more privileged one
+func HasRole(user *persistence.User, role model.Role) bool {
+	if user == nil {
+		return false
+	}
+	userRank, ok := roleRank[user.Role]
+	if !ok {
+		return false
+	}
+	return userRank >= roleRank[role]
+}
+
+// RequireRole returns the user from the context if they hold at least the given role
+func RequireRole(ctx context.Context, role model.Role) (*persistence.User, error) {
+	user := ForContext(ctx)
+	if user == nil {
+		return nil, ErrUnauthenticated
+	}
+	if !HasRole(user, role) {
+		return nil, fmt.Errorf("permission denied: requires %s role", role)
+	}
+	return user, nil
+}
@@ -0,0 +1,288 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"graphql-go/persistence"
+)
+
+const (
+	defaultAccessTokenTTL  = 15 * time.Minute
+	defaultRefreshTokenTTL = 14 * 24 * time.Hour
+)
+
+var ErrInvalidRefreshToken = errors.New("Invalid refresh token")
+
+// TokenPair is returned to clients on login and on every refresh
+type TokenPair struct {
+	AccessToken  string `json:"accessToken"`
+	RefreshToken string `json:"refreshToken"`
+	ExpiresIn    int    `json:"expiresIn"` // lifetime of the access token in seconds
+}
+
+// AccessClaims are the claims of the access tokens we issue
+type AccessClaims struct {
+	jwt.RegisteredClaims
+	SessionID string `json:"sid"`
+	// Actor is set on impersonation tokens and names the admin acting as the subject
+	Actor *Actor `json:"act,omitempty"`
+}
+
+// accessTokenTTL reads ACCESS_TOKEN_TTL (e.g. "15m"), falling back to the default
+func accessTokenTTL() time.Duration {
+	return durationFromEnv("ACCESS_TOKEN_TTL", defaultAccessTokenTTL)
+}
+
+// refreshTokenTTL reads REFRESH_TOKEN_TTL (e.g. "336h"), falling back to the default
+func refreshTokenTTL() time.Duration {
+	return durationFromEnv("REFRESH_TOKEN_TTL", defaultRefreshTokenTTL)
+}
+
+func durationFromEnv(key string, fallback time.Duration) time.Duration {
+	if d, err := time.ParseDuration(os.Getenv(key)); err == nil && d > 0 {
+		return d
+	}
+	return fallback
+}
+
+// IssueTokens starts a new session for the user and returns its first token pair
+func IssueTokens(db *gorm.DB, user *persistence.User) (*TokenPair, error) {
+	refreshToken, err := generateRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+
+	session := &persistence.Session{
+		ID:               uuid.New().String(),
+		UserId:           user.ID,
+		RefreshTokenHash: hashToken(refreshToken),
+		ExpiresAt:        time.Now().Add(refreshTokenTTL()),
+	}
+	if err := db.Create(session).Error; err != nil {
+		return nil, err
+	}
+
+	return tokenPair(user.ID, session.ID, refreshToken)
+}
+
+// RefreshTokens rotates the refresh token of a session and issues a new access token. Presenting
+// a refresh token that was already rotated revokes the whole session, since it has leaked.
+func RefreshTokens(db *gorm.DB, refreshToken string) (*TokenPair, error) {
+	hash := hashToken(refreshToken)
+
+	session := &persistence.Session{}
+	res := db.Where("refresh_token_hash = ? AND cookie = ?", hash, false).Limit(1).Find(session)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	if res.RowsAffected == 0 {
+		// Reuse of a rotated token, revoke the session it belonged to
+		reused := db.Model(&persistence.Session{}).
+			Where("previous_refresh_token_hash = ? AND revoked_at IS NULL", hash).
+			Update("revoked_at", time.Now())
+		if reused.Error != nil {
+			return nil, reused.Error
+		}
+		return nil, ErrInvalidRefreshToken
+	}
+	if session.RevokedAt != nil || time.Now().After(session.ExpiresAt) {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	newRefreshToken, err := generateRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+
+	// Only rotate if nobody else rotated the token since we read it
+	rotated := db.Model(&persistence.Session{}).
+		Where("id = ? AND refresh_token_hash = ? AND revoked_at IS NULL", session.ID, hash).
+		Updates(map[string]interface{}{
+			"previous_refresh_token_hash": hash,
+			"refresh_token_hash":          hashToken(newRefreshToken),
+			"expires_at":                  time.Now().Add(refreshTokenTTL()),
+		})
+	if rotated.Error != nil {
+		return nil, rotated.Error
+	}
+	if rotated.RowsAffected == 0 {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	return tokenPair(session.UserId, session.ID, newRefreshToken)
+}
+
+// RevokeSession revokes a single session, invalidating its refresh token and access tokens
+func RevokeSession(db *gorm.DB, sessionID string) error {
+	return db.Model(&persistence.Session{}).
+		Where("id = ? AND revoked_at IS NULL", sessionID).
+		Update("revoked_at", time.Now()).Error
+}
+
+// RevokeAccessToken rejects the access token with the given id until it expires
+func RevokeAccessToken(db *gorm.DB, claims *AccessClaims) error {
+	// Expired entries are of no use anymore, so clean them up while we are here
+	if err := db.Where("expires_at < ?", time.Now()).Delete(&persistence.RevokedToken{}).Error; err != nil {
+		return err
+	}
+
+	return db.Save(&persistence.RevokedToken{ID: claims.ID, ExpiresAt: claims.ExpiresAt.Time}).Error
+}
+
+// isRevoked reports whether the access token or the session it belongs to has been revoked
+func isRevoked(db *gorm.DB, claims *AccessClaims) (bool, error) {
+	var count int64
+	if err := db.Model(&persistence.RevokedToken{}).Where("id = ?", claims.ID).Count(&count).Error; err != nil {
+		return false, err
+	}
+	if count > 0 {
+		return true, nil
+	}
+
+	if err := db.Model(&persistence.Session{}).
+		Where("id = ? AND revoked_at IS NULL", claims.SessionID).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count == 0, nil
+}
+
+func tokenPair(userID string, sessionID string, refreshToken string) (*TokenPair, error) {
+	accessToken, err := signAccessToken(userID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int(accessTokenTTL().Seconds()),
+	}, nil
+}
+
+func signAccessToken(userID string, sessionID string) (string, error) {
+	return keys.sign(newAccessClaims(userID, sessionID, accessTokenTTL()))
+}
+
+func newAccessClaims(userID string, sessionID string, ttl time.Duration) AccessClaims {
+	now := time.Now()
+	return AccessClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.New().String(),
+			Subject:   userID,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+		SessionID: sessionID,
+	}
+}
+
+func generateRefreshToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashToken hashes opaque tokens before they are stored, so a database leak does not leak sessions
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// HandleRefresh exchanges a refresh token, posted as {"refreshToken": "..."}, for a new token pair
+func HandleRefresh(db *gorm.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		db := db.WithContext(r.Context())
+
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		var body struct {
+			RefreshToken string `json:"refreshToken"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.RefreshToken == "" {
+			http.Error(w, "Missing refresh token", http.StatusBadRequest)
+			return
+		}
+
+		pair, err := RefreshTokens(db, body.RefreshToken)
+		if errors.Is(err, ErrInvalidRefreshToken) {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		if err != nil {
+			http.Error(w, "Failed to refresh token: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		writeTokenPair(w, pair)
+	}
+}
+
+// HandleLogout revokes the session and access token presented in the Authorization header,
+// or the session behind the session cookie
+func HandleLogout(db *gorm.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		db := db.WithContext(r.Context())
+
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		// Cookie sessions are logged out by revoking the session behind the cookie
+		if cookie, err := r.Cookie(SessionCookieName); err == nil && r.Header.Get("Authorization") == "" {
+			if !trustedOrigin(r) {
+				http.Error(w, ErrCrossOrigin.Error(), http.StatusForbidden)
+				return
+			}
+			if session, err := cookieSession(db, cookie.Value); err == nil {
+				if err := RevokeSession(db, session.ID); err != nil {
+					http.Error(w, "Failed to revoke session: "+err.Error(), http.StatusInternalServerError)
+					return
+				}
+			}
+			clearSessionCookie(w)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		claims, err := parseAuthorization(r.Header.Get("Authorization"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		if err := RevokeSession(db, claims.SessionID); err != nil {
+			http.Error(w, "Failed to revoke session: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if err := RevokeAccessToken(db, claims); err != nil {
+			http.Error(w, "Failed to revoke token: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func writeTokenPair(w http.ResponseWriter, pair *TokenPair) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(pair)
+}
@@ -0,0 +1,109 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"graphql-go/graph/model"
+	"graphql-go/persistence"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+func addTestUser(t *testing.T, db *gorm.DB, id string, role model.Role) *persistence.User {
+	t.Helper()
+
+	user := &persistence.User{ID: id, Name: id, Email: id + "@example.com", Role: role}
+	if err := db.Create(user).Error; err != nil {
+		t.Fatal(err)
+	}
+	return user
+}
+
+// refresh posts the refresh token to HandleRefresh and returns the status and the new pair
+func refresh(t *testing.T, db *gorm.DB, refreshToken string) (int, *TokenPair) {
+	t.Helper()
+
+	r := httptest.NewRequest(http.MethodPost, "/refresh", strings.NewReader(`{"refreshToken": "`+refreshToken+`"}`))
+	w := httptest.NewRecorder()
+	HandleRefresh(db)(w, r)
+	if w.Code != http.StatusOK {
+		return w.Code, nil
+	}
+
+	pair := &TokenPair{}
+	if err := json.NewDecoder(w.Body).Decode(pair); err != nil {
+		t.Fatal(err)
+	}
+	return w.Code, pair
+}
+
+func TestRefreshTokenRotation(t *testing.T) {
+	db := newTestDB(t)
+	user := addTestUser(t, db, "member", model.RoleMember)
+
+	first, err := IssueTokens(db, user)
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, second := refresh(t, db, first.RefreshToken)
+	if code != http.StatusOK {
+		t.Fatalf("refreshing: got status %d", code)
+	}
+	if second.RefreshToken == first.RefreshToken || second.ExpiresIn <= 0 {
+		t.Errorf("got %+v, want a new refresh token", second)
+	}
+	for _, pair := range []*TokenPair{first, second} {
+		if _, err := Authenticate(context.Background(), db, "Bearer "+pair.AccessToken); err != nil {
+			t.Errorf("authenticating with an access token of the session: %v", err)
+		}
+	}
+
+	if code, _ := refresh(t, db, "unknown"); code != http.StatusUnauthorized {
+		t.Errorf("refreshing an unknown token: got status %d, want 401", code)
+	}
+}
+
+// A rotated refresh token showing up again has leaked, whoever holds the current one may be the
+// thief. The session is revoked along with every token it issued.
+func TestRefreshTokenReuse(t *testing.T) {
+	db := newTestDB(t)
+	user := addTestUser(t, db, "member", model.RoleMember)
+
+	first, err := IssueTokens(db, user)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := IssueTokens(db, user)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, second := refresh(t, db, first.RefreshToken)
+	if second == nil {
+		t.Fatal("refreshing failed")
+	}
+
+	if code, _ := refresh(t, db, first.RefreshToken); code != http.StatusUnauthorized {
+		t.Errorf("reusing a rotated refresh token: got status %d, want 401", code)
+	}
+	if code, _ := refresh(t, db, second.RefreshToken); code != http.StatusUnauthorized {
+		t.Errorf("refreshing after the reuse: got status %d, want 401", code)
+	}
+	for _, pair := range []*TokenPair{first, second} {
+		if _, err := Authenticate(context.Background(), db, "Bearer "+pair.AccessToken); !errors.Is(err, ErrInvalidToken) {
+			t.Errorf("authenticating after the reuse: got %v, want ErrInvalidToken", err)
+		}
+	}
+
+	// Other sessions of the user are not part of the family
+	if _, err := Authenticate(context.Background(), db, "Bearer "+other.AccessToken); err != nil {
+		t.Errorf("authenticating with another session: %v", err)
+	}
+	if code, _ := refresh(t, db, other.RefreshToken); code != http.StatusOK {
+		t.Errorf("refreshing another session: got status %d, want 200", code)
+	}
+}
@@ -0,0 +1,64 @@
+// Package office holds the timezone of the office. Burger days follow its calendar, whatever
+// the timezone of the server.
+package office
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	// The alpine image has no zoneinfo
+	_ "time/tzdata"
+)
+
+const (
+	defaultTimezone = "Europe/Copenhagen"
+	// DateLayout formats the date of a burger day
+	DateLayout = "2006-01-02"
+)
+
+var location = mustLoadLocation(defaultTimezone)
+
+func mustLoadLocation(name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		panic(err)
+	}
+	return loc
+}
+
+// Configure sets the timezone from OFFICE_TIMEZONE, an IANA name like Europe/Copenhagen
+func Configure() error {
+	name := os.Getenv("OFFICE_TIMEZONE")
+	if name == "" {
+		return nil
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return fmt.Errorf("invalid OFFICE_TIMEZONE: %w", err)
+	}
+	location = loc
+	return nil
+}
+
+// Location returns the timezone of the office
+func Location() *time.Location {
+	return location
+}
+
+// Today returns the current date in the office, formatted as YYYY-MM-DD
+func Today() string {
+	return time.Now().In(location).Format(DateLayout)
+}
+
+// Noon returns 12:00 in the office on the date, formatted as YYYY-MM-DD. Burgers are delivered
+// then unless the host says otherwise.
+func Noon(date string) (time.Time, error) {
+	day, err := time.ParseInLocation(DateLayout, date, location)
+	if err != nil {
+		return time.Time{}, err
+	}
+	// Adding 12 hours would be off by one on the days daylight saving time starts or ends
+	return time.Date(day.Year(), day.Month(), day.Day(), 12, 0, 0, 0, location), nil
+}
@@ -1,5 +1,7 @@
 package stats
 
+import "fmt"
+
 type ErrBurgerDayClosed struct {
 }
 
@@ -10,3 +12,33 @@ func (e ErrBurgerDayClosed) Error() string {
 func (e ErrBurgerDayClosed) ToString() string {
 	return "burger day is closed"
 }
+
+// ErrNotFound is returned for ids of things that do not exist, clients get it with the code
+// NOT_FOUND
+type ErrNotFound struct {
+	Resource string
+	ID       string
+}
+
+func (e ErrNotFound) Error() string {
+	return fmt.Sprintf("%s %s not found", e.Resource, e.ID)
+}
+
+func (e ErrNotFound) ToString() string {
+	return e.Error()
+}
+
+// ErrConflict is returned when saving something that was changed since the client read it,
+// clients get it with the code CONFLICT and should reload before trying again
+type ErrConflict struct {
+	Resource string
+	ID       string
+}
+
+func (e ErrConflict) Error() string {
+	return fmt.Sprintf("%s %s was changed in the meantime, reload it and try again", e.Resource, e.ID)
+}
+
+func (e ErrConflict) ToString() string {
+	return e.Error()
+}
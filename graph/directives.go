@@ -0,0 +1,92 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"graphql-go/auth"
+	"graphql-go/graph/model"
+	"graphql-go/persistence"
+	"strings"
+
+	"github.com/99designs/gqlgen/graphql"
+)
+
+// NewDirectives returns the implementations of the authorization directives declared in the schema
+func NewDirectives(repos persistence.Repositories) DirectiveRoot {
+	return DirectiveRoot{
+		Authenticated: authenticated,
+		HasRole:       hasRole,
+		IsOwner: func(ctx context.Context, obj interface{}, next graphql.Resolver, of model.OwnedResource, idArg string) (interface{}, error) {
+			return isOwner(ctx, repos, next, of, idArg)
+		},
+	}
+}
+
+func authenticated(ctx context.Context, obj interface{}, next graphql.Resolver) (interface{}, error) {
+	if auth.ForContext(ctx) == nil {
+		return nil, auth.ErrUnauthenticated
+	}
+	return next(ctx)
+}
+
+func hasRole(ctx context.Context, obj interface{}, next graphql.Resolver, role model.Role) (interface{}, error) {
+	if _, err := auth.RequireRole(ctx, role); err != nil {
+		return nil, err
+	}
+	return next(ctx)
+}
+
+func isOwner(ctx context.Context, repos persistence.Repositories, next graphql.Resolver, of model.OwnedResource, idArg string) (interface{}, error) {
+	user := auth.ForContext(ctx)
+	if user == nil {
+		return nil, auth.ErrUnauthenticated
+	}
+	if auth.HasRole(user, model.RoleAdmin) {
+		return next(ctx)
+	}
+
+	id, _ := graphql.GetFieldContext(ctx).Args[idArg].(string)
+	owner, err := ownsResource(ctx, repos, user, of, id)
+	if err != nil {
+		return nil, err
+	}
+	if !owner {
+		return nil, fmt.Errorf("permission denied: only the owner can modify this %s", strings.ToLower(strings.ReplaceAll(string(of), "_", " ")))
+	}
+
+	return next(ctx)
+}
+
+// ownsResource checks whether the user owns the burger day or order with the given id.
+// Orders are owned both by the user who placed them and by the author of their burger day,
+// while ORDER_BURGER_DAY only accepts the author of the burger day the order was placed on.
+func ownsResource(ctx context.Context, repos persistence.Repositories, user *persistence.User, of model.OwnedResource, id string) (bool, error) {
+	switch of {
+	case model.OwnedResourceBurgerDay:
+		burgerDay, err := repos.BurgerDays.Get(ctx, id)
+		if err != nil {
+			return false, notFound(err, "burger day", id)
+		}
+		return burgerDay.AuthorId == user.ID, nil
+	case model.OwnedResourceOrder:
+		order, err := repos.Orders.Get(ctx, id)
+		if err != nil {
+			return false, notFound(err, "order", id)
+		}
+		if order.UserId == user.ID {
+			return true, nil
+		}
+		return ownsResource(ctx, repos, user, model.OwnedResourceOrderBurgerDay, id)
+	case model.OwnedResourceOrderBurgerDay:
+		order, err := repos.Orders.Get(ctx, id)
+		if err != nil {
+			return false, notFound(err, "order", id)
+		}
+		burgerDay, err := repos.BurgerDays.Get(ctx, order.BurgerDayId)
+		if err != nil {
+			return false, notFound(err, "burger day", order.BurgerDayId)
+		}
+		return burgerDay.AuthorId == user.ID, nil
+	}
+	return false, fmt.Errorf("unknown owned resource: %s", of)
+}
@@ -0,0 +1,160 @@
+package graph
+
+import (
+	"context"
+	"graphql-go/graph/model"
+	"graphql-go/persistence"
+	"strings"
+	"testing"
+
+	"github.com/99designs/gqlgen/client"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+func TestIsOwner(t *testing.T) {
+	repos := persistence.NewMemoryRepositories()
+	c := newTestClient(repos)
+
+	host := addUser(t, repos, "host", model.RoleHost)
+	member := addUser(t, repos, "member", model.RoleMember)
+	other := addUser(t, repos, "other", model.RoleMember)
+	admin := addUser(t, repos, "admin", model.RoleAdmin)
+	burgerDay := addBurgerDay(t, repos, "day", "2024-03-21", host)
+	addOrder(t, repos, "order", burgerDay, member)
+
+	const markPaid = `mutation($id: ID!) { markPaid(orderId: $id) { paid } }`
+	const deleteOrder = `mutation($id: ID!) { delete_order(orderId: $id) }`
+	const closeBurgerDay = `mutation($id: ID!) { close_burger_day(burgerDayId: $id) { closed } }`
+
+	tests := []struct {
+		name    string
+		user    *persistence.User
+		query   string
+		id      string
+		allowed bool
+	}{
+		{"orderer cannot mark their own order paid", member, markPaid, "order", false},
+		{"others cannot mark the order paid", other, markPaid, "order", false},
+		{"the host marks orders of their day paid", host, markPaid, "order", true},
+		{"others cannot delete the order", other, deleteOrder, "order", false},
+		{"others cannot close the day", other, closeBurgerDay, "day", false},
+		{"the host closes their day", host, closeBurgerDay, "day", true},
+		{"admins delete any order", admin, deleteOrder, "order", true},
+	}
+	for _, test := range tests {
+		var resp map[string]interface{}
+		err := c.Post(test.query, &resp, as(test.user), client.Var("id", test.id))
+		if test.allowed && err != nil {
+			t.Errorf("%s: %v", test.name, err)
+		}
+		if !test.allowed {
+			if err == nil {
+				t.Errorf("%s: was allowed", test.name)
+				continue
+			}
+			if message, _ := errorMessage(t, err); !strings.Contains(message, "permission denied") {
+				t.Errorf("%s: got %q, want permission denied", test.name, message)
+			}
+		}
+	}
+}
+
+func TestIsOwnerNotFound(t *testing.T) {
+	repos := persistence.NewMemoryRepositories()
+	c := newTestClient(repos)
+
+	host := addUser(t, repos, "host", model.RoleHost)
+	member := addUser(t, repos, "member", model.RoleMember)
+	burgerDay := addBurgerDay(t, repos, "day", "2024-03-21", host)
+	addOrder(t, repos, "order", burgerDay, member)
+	if err := repos.Orders.Delete(context.Background(), "order", host.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, query := range []string{
+		`mutation { markPaid(orderId: "order") { paid } }`,
+		`mutation { delete_order(orderId: "missing") }`,
+		`mutation { close_burger_day(burgerDayId: "missing") { closed } }`,
+	} {
+		var resp map[string]interface{}
+		err := c.Post(query, &resp, as(member))
+		if err == nil {
+			t.Errorf("%s: succeeded", query)
+			continue
+		}
+		if _, code := errorMessage(t, err); code != "NOT_FOUND" {
+			t.Errorf("%s: got code %q, want NOT_FOUND", query, code)
+		}
+	}
+}
+
+// Every operation states who may run it in the schema, nothing relies on the HTTP middleware alone
+func TestOperationsDeclarePermissions(t *testing.T) {
+	schema := NewExecutableSchema(Config{}).Schema()
+	for _, operation := range []*ast.Definition{schema.Query, schema.Mutation, schema.Subscription} {
+		for _, field := range operation.Fields {
+			if strings.HasPrefix(field.Name, "__") {
+				continue
+			}
+			declared := false
+			for _, name := range []string{"authenticated", "hasRole", "isOwner"} {
+				if field.Directives.ForName(name) != nil {
+					declared = true
+				}
+			}
+			if !declared {
+				t.Errorf("%s.%s has no authorization directive", operation.Name, field.Name)
+			}
+		}
+	}
+}
+
+func TestAnonymousRejected(t *testing.T) {
+	repos := persistence.NewMemoryRepositories()
+	c := newTestClient(repos)
+
+	host := addUser(t, repos, "host", model.RoleHost)
+	burgerDay := addBurgerDay(t, repos, "day", "2024-03-21", host)
+	addOrder(t, repos, "order", burgerDay, host)
+
+	for _, query := range []string{
+		`query { accumulated_orders { count } }`,
+		`query { burger_day(id: "day") { id } }`,
+		`query { burger_days { id } }`,
+		`query { burgerStats { totalOrders } }`,
+		`query { order(id: "order") { id } }`,
+		`query { orders { id } }`,
+		`query { todays_burgers { id } }`,
+		`query { user(id: "host") { id } }`,
+		`query { users { id } }`,
+		`mutation { ringBurgerBell(message: "Burgers are here") }`,
+	} {
+		var resp map[string]interface{}
+		err := c.Post(query, &resp)
+		if err == nil {
+			t.Errorf("%s: succeeded without a user", query)
+			continue
+		}
+		if message, _ := errorMessage(t, err); !strings.Contains(message, "unauthenticated") {
+			t.Errorf("%s: got %q, want unauthenticated", query, message)
+		}
+	}
+}
+
+func TestRingBurgerBell(t *testing.T) {
+	repos := persistence.NewMemoryRepositories()
+	c := newTestClient(repos)
+
+	member := addUser(t, repos, "member", model.RoleMember)
+	host := addUser(t, repos, "host", model.RoleHost)
+
+	const ring = `mutation { ringBurgerBell(message: "Burgers are here") }`
+	var resp map[string]interface{}
+	err := c.Post(ring, &resp, as(member))
+	if message, _ := errorMessage(t, err); !strings.Contains(message, "permission denied") {
+		t.Errorf("members ringing the bell: got %q, want permission denied", message)
+	}
+	if err := c.Post(ring, &resp, as(host)); err != nil {
+		t.Errorf("hosts ringing the bell: %v", err)
+	}
+}
@@ -0,0 +1,34 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"graphql-go/core/stats"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// ErrorPresenter adds a code to the extensions of errors clients are expected to handle,
+// e.g. {"code": "NOT_FOUND"}
+func ErrorPresenter(ctx context.Context, err error) *gqlerror.Error {
+	gqlErr := graphql.DefaultErrorPresenter(ctx, err)
+
+	var notFound stats.ErrNotFound
+	var conflict stats.ErrConflict
+	switch {
+	case errors.As(err, &notFound):
+		setCode(gqlErr, "NOT_FOUND")
+	case errors.As(err, &conflict):
+		setCode(gqlErr, "CONFLICT")
+	}
+
+	return gqlErr
+}
+
+func setCode(gqlErr *gqlerror.Error, code string) {
+	if gqlErr.Extensions == nil {
+		gqlErr.Extensions = map[string]interface{}{}
+	}
+	gqlErr.Extensions["code"] = code
+}
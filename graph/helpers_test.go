@@ -0,0 +1,89 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"graphql-go/auth"
+	"graphql-go/core/office"
+	"graphql-go/graph/model"
+	"graphql-go/persistence"
+	"testing"
+
+	"github.com/99designs/gqlgen/client"
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+)
+
+// newTestClient serves the schema with its directives against the repositories, the way
+// server.go does against the database
+func newTestClient(repos persistence.Repositories) *client.Client {
+	srv := handler.New(NewExecutableSchema(Config{
+		Resolvers:  NewResolver(repos),
+		Directives: NewDirectives(repos),
+	}))
+	srv.AddTransport(transport.POST{})
+	srv.SetErrorPresenter(ErrorPresenter)
+	return client.New(srv)
+}
+
+// as sends the request on behalf of the user
+func as(user *persistence.User) client.Option {
+	return func(request *client.Request) {
+		request.HTTP = request.HTTP.WithContext(auth.WithUser(request.HTTP.Context(), user))
+	}
+}
+
+func addUser(t *testing.T, repos persistence.Repositories, id string, role model.Role) *persistence.User {
+	t.Helper()
+
+	user := &persistence.User{ID: id, Name: id, Email: id + "@example.com", Role: role}
+	if err := repos.Users.Create(context.Background(), user); err != nil {
+		t.Fatal(err)
+	}
+	return user
+}
+
+func addBurgerDay(t *testing.T, repos persistence.Repositories, id string, date string, author *persistence.User) *persistence.BurgerDay {
+	t.Helper()
+
+	noon, err := office.Noon(date)
+	if err != nil {
+		t.Fatal(err)
+	}
+	burgerDay := &persistence.BurgerDay{ID: id, AuthorId: author.ID, Date: persistence.Date(date), EstimatedTime: noon}
+	if err := repos.BurgerDays.Create(context.Background(), burgerDay); err != nil {
+		t.Fatal(err)
+	}
+	return burgerDay
+}
+
+func addOrder(t *testing.T, repos persistence.Repositories, id string, burgerDay *persistence.BurgerDay, user *persistence.User) *persistence.Order {
+	t.Helper()
+
+	order := &persistence.Order{ID: id, BurgerDayId: burgerDay.ID, UserId: user.ID, SpecialRequest: persistence.StringArray{}}
+	if err := repos.Orders.Create(context.Background(), order); err != nil {
+		t.Fatal(err)
+	}
+	return order
+}
+
+// errorMessage returns the message and code of the first error of a failed request
+func errorMessage(t *testing.T, err error) (string, string) {
+	t.Helper()
+
+	var raw client.RawJsonError
+	if !errors.As(err, &raw) {
+		t.Fatalf("got %v, want a GraphQL error", err)
+	}
+	var errs []struct {
+		Message    string
+		Extensions struct {
+			Code string
+		}
+	}
+	if err := json.Unmarshal(raw.RawMessage, &errs); err != nil || len(errs) == 0 {
+		t.Fatalf("unexpected errors %s", raw.RawMessage)
+	}
+	return errs[0].Message, errs[0].Extensions.Code
+}
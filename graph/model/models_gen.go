@@ -36,6 +36,17 @@ type Consumer struct {
 	User            *User `json:"user"`
 }
 
+type CreatedAPIToken struct {
+	APIToken *APIToken `json:"apiToken"`
+	Token    string    `json:"token"`
+}
+
+type Impersonation struct {
+	AccessToken string `json:"accessToken"`
+	ExpiresIn   int    `json:"expiresIn"`
+	User        *User  `json:"user"`
+}
+
 type Mutation struct {
 }
 
@@ -46,10 +57,103 @@ type Subscription struct {
 }
 
 type User struct {
-	Email       string  `json:"email"`
-	ID          string  `json:"id"`
-	Name        string  `json:"name"`
-	PhoneNumber *string `json:"phoneNumber,omitempty"`
+	Email          string   `json:"email"`
+	ID             string   `json:"id"`
+	Name           string   `json:"name"`
+	PhoneNumber    *string  `json:"phoneNumber,omitempty"`
+	Role           Role     `json:"role"`
+	ServiceAccount bool     `json:"serviceAccount"`
+	Teams          []string `json:"teams"`
+	Deactivated    bool     `json:"deactivated"`
+	Version        int      `json:"version"`
+}
+
+type OwnedResource string
+
+const (
+	OwnedResourceBurgerDay      OwnedResource = "BURGER_DAY"
+	OwnedResourceOrder          OwnedResource = "ORDER"
+	OwnedResourceOrderBurgerDay OwnedResource = "ORDER_BURGER_DAY"
+)
+
+var AllOwnedResource = []OwnedResource{
+	OwnedResourceBurgerDay,
+	OwnedResourceOrder,
+	OwnedResourceOrderBurgerDay,
+}
+
+func (e OwnedResource) IsValid() bool {
+	switch e {
+	case OwnedResourceBurgerDay, OwnedResourceOrder, OwnedResourceOrderBurgerDay:
+		return true
+	}
+	return false
+}
+
+func (e OwnedResource) String() string {
+	return string(e)
+}
+
+func (e *OwnedResource) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = OwnedResource(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid OwnedResource", str)
+	}
+	return nil
+}
+
+func (e OwnedResource) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type Role string
+
+const (
+	RoleAdmin  Role = "ADMIN"
+	RoleHost   Role = "HOST"
+	RoleMember Role = "MEMBER"
+	RoleGuest  Role = "GUEST"
+)
+
+var AllRole = []Role{
+	RoleAdmin,
+	RoleHost,
+	RoleMember,
+	RoleGuest,
+}
+
+func (e Role) IsValid() bool {
+	switch e {
+	case RoleAdmin, RoleHost, RoleMember, RoleGuest:
+		return true
+	}
+	return false
+}
+
+func (e Role) String() string {
+	return string(e)
+}
+
+func (e *Role) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = Role(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid Role", str)
+	}
+	return nil
+}
+
+func (e Role) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
 type SpecialOrders string
@@ -104,3 +208,44 @@ func (e *SpecialOrders) UnmarshalGQL(v interface{}) error {
 func (e SpecialOrders) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
+
+type TokenScope string
+
+const (
+	TokenScopeRead  TokenScope = "READ"
+	TokenScopeWrite TokenScope = "WRITE"
+)
+
+var AllTokenScope = []TokenScope{
+	TokenScopeRead,
+	TokenScopeWrite,
+}
+
+func (e TokenScope) IsValid() bool {
+	switch e {
+	case TokenScopeRead, TokenScopeWrite:
+		return true
+	}
+	return false
+}
+
+func (e TokenScope) String() string {
+	return string(e)
+}
+
+func (e *TokenScope) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = TokenScope(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid TokenScope", str)
+	}
+	return nil
+}
+
+func (e TokenScope) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
@@ -0,0 +1,46 @@
+package model
+
+import (
+	"fmt"
+	"graphql-go/core/office"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+)
+
+// MarshalDate writes a date, which is already formatted as YYYY-MM-DD
+func MarshalDate(date string) graphql.Marshaler {
+	return graphql.MarshalString(date)
+}
+
+// UnmarshalDate accepts a date formatted as YYYY-MM-DD
+func UnmarshalDate(v interface{}) (string, error) {
+	date, ok := v.(string)
+	if !ok {
+		return "", fmt.Errorf("date must be a string formatted as YYYY-MM-DD")
+	}
+	if _, err := time.Parse(office.DateLayout, date); err != nil {
+		return "", fmt.Errorf("invalid date %q, expected YYYY-MM-DD", date)
+	}
+	return date, nil
+}
+
+// MarshalTime writes the time as RFC3339 in the office timezone, so clients read the office's
+// clock without converting
+func MarshalTime(t time.Time) graphql.Marshaler {
+	return graphql.MarshalString(t.In(office.Location()).Format(time.RFC3339))
+}
+
+// UnmarshalTime accepts a time formatted as RFC3339. The offset is required, a bare time of
+// day like 12:30 is ambiguous.
+func UnmarshalTime(v interface{}) (time.Time, error) {
+	value, ok := v.(string)
+	if !ok {
+		return time.Time{}, fmt.Errorf("time must be a string formatted as RFC3339")
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q, expected RFC3339 like 2006-01-02T12:00:00+01:00", value)
+	}
+	return t.In(office.Location()), nil
+}
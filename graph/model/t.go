@@ -1,5 +1,7 @@
 package model
 
+import "time"
+
 type Order struct {
 	ID             string          `json:"id"`
 	BurgerDay      *BurgerDay      `json:"BurgerDay"`
@@ -7,16 +9,37 @@ type Order struct {
 	User           *User           `json:"user"`
 	UserId         string          `json:"userId"`
 	Paid           bool            `json:"payed"`
+	PaidAt         *string         `json:"paidAt"`
+	PaidBy         *User           `json:"paidBy"`
+	PaidById       *string         `json:"paidById"`
 	SpecialRequest []SpecialOrders `json:"specialRequest"`
+	DeletedAt      *string         `json:"deletedAt"`
+	DeletedBy      *User           `json:"deletedBy"`
+	DeletedById    *string         `json:"deletedById"`
 }
 
 type BurgerDay struct {
-	ID            string   `json:"id"`
-	Author        *User    `json:"author"`
-	AuthorId      string   `json:"authorId"`
-	Date          string   `json:"date"`
-	Closed        bool     `json:"closed"`
-	EstimatedTime string   `json:"estimatedDeliveryTime"`
-	Price         float64  `json:"price"`
-	Orders        []*Order `json:"orders"`
+	ID            string    `json:"id"`
+	Author        *User     `json:"author"`
+	AuthorId      string    `json:"authorId"`
+	Date          string    `json:"date"`
+	Closed        bool      `json:"closed"`
+	EstimatedTime time.Time `json:"estimatedDeliveryTime"`
+	Version       int       `json:"version"`
+	Price         float64   `json:"price"`
+	Orders        []*Order  `json:"orders"`
+	DeletedAt     *string   `json:"deletedAt"`
+	DeletedBy     *User     `json:"deletedBy"`
+	DeletedById   *string   `json:"deletedById"`
+}
+
+type APIToken struct {
+	ID         string       `json:"id"`
+	Name       string       `json:"name"`
+	User       *User        `json:"user"`
+	UserId     string       `json:"userId"`
+	Scopes     []TokenScope `json:"scopes"`
+	CreatedAt  string       `json:"createdAt"`
+	ExpiresAt  string       `json:"expiresAt"`
+	LastUsedAt *string      `json:"lastUsedAt"`
 }
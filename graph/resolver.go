@@ -2,10 +2,10 @@ package graph
 
 import (
 	"graphql-go/graph/model"
+	"graphql-go/persistence"
 	"sync"
 
 	"github.com/google/uuid"
-	"gorm.io/gorm"
 )
 
 //go:generate go run github.com/99designs/gqlgen generate
@@ -14,16 +14,18 @@ import (
 //
 // It serves as dependency injection for your app, add any dependencies you require here.
 
+// Resolver reads and writes everything through the repositories, so the resolvers also run
+// against the in-memory ones.
 type Resolver struct {
-	DB               *gorm.DB
+	Repos            persistence.Repositories
 	subscribers      map[string]chan *model.BurgerBellEvent
 	subscribersMutex sync.Mutex
 	BurgerBellChan   chan *model.BurgerBellEvent // Keep for backward compatibility
 }
 
-func NewResolver(db *gorm.DB) *Resolver {
+func NewResolver(repos persistence.Repositories) *Resolver {
 	r := &Resolver{
-		DB:             db,
+		Repos:          repos,
 		subscribers:    make(map[string]chan *model.BurgerBellEvent),
 		BurgerBellChan: make(chan *model.BurgerBellEvent, 1),
 	}
@@ -7,24 +7,33 @@ package graph
 import (
 	"context"
 	"errors"
+	"fmt"
 	"graphql-go/auth"
+	"graphql-go/core/office"
 	"graphql-go/core/stats"
 	"graphql-go/graph/model"
 	"graphql-go/persistence"
-	"slices"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
-	"gorm.io/gorm"
 )
 
+// User is the resolver for the user field.
+func (r *apiTokenResolver) User(ctx context.Context, obj *model.APIToken) (*model.User, error) {
+	user, err := r.Repos.Users.Get(ctx, obj.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	return persistence.UserToModel(user), nil
+}
+
 // Author is the resolver for the author field.
 func (r *burgerDayResolver) Author(ctx context.Context, obj *model.BurgerDay) (*model.User, error) {
-	user := &persistence.User{ID: obj.AuthorId}
-	res := r.DB.First(user)
-	if res.Error != nil {
-		return nil, res.Error // It's better to return the error rather than panic, to handle it gracefully
+	user, err := r.Repos.Users.Get(ctx, obj.AuthorId)
+	if err != nil {
+		return nil, err // It's better to return the error rather than panic, to handle it gracefully
 	}
 
 	// Assuming persistence.UserToModel converts a persistence.User to a *model.User
@@ -33,11 +42,9 @@ func (r *burgerDayResolver) Author(ctx context.Context, obj *model.BurgerDay) (*
 
 // Orders is the resolver for the orders field.
 func (r *burgerDayResolver) Orders(ctx context.Context, obj *model.BurgerDay) ([]*model.Order, error) {
-	var orders []*persistence.Order
-
-	res := r.DB.Where("burger_day_id = ?", obj.ID).Find(&orders)
-	if res.Error != nil {
-		return nil, res.Error
+	orders, err := r.Repos.Orders.ListByBurgerDay(ctx, obj.ID)
+	if err != nil {
+		return nil, err
 	}
 
 	return persistence.OrdersToModels(orders), nil
@@ -45,28 +52,33 @@ func (r *burgerDayResolver) Orders(ctx context.Context, obj *model.BurgerDay) ([
 
 // OrdersCount is the resolver for the ordersCount field.
 func (r *burgerDayResolver) OrdersCount(ctx context.Context, obj *model.BurgerDay) (int, error) {
-	var count int64
-	err := r.DB.Model(&persistence.Order{}).Where("burger_day_id = ?", obj.ID).Count(&count).Error
+	return r.Repos.Orders.CountByBurgerDay(ctx, obj.ID)
+}
+
+// DeletedBy is the resolver for the deletedBy field.
+func (r *burgerDayResolver) DeletedBy(ctx context.Context, obj *model.BurgerDay) (*model.User, error) {
+	if obj.DeletedById == nil {
+		return nil, nil
+	}
+
+	user, err := r.Repos.Users.Get(ctx, *obj.DeletedById)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
-	return int(count), nil
+
+	return persistence.UserToModel(user), nil
 }
 
 // CloseBurgerDay is the resolver for the close_burger_day field.
 func (r *mutationResolver) CloseBurgerDay(ctx context.Context, burgerDayID string) (*model.BurgerDay, error) {
-	burgerDay := &persistence.BurgerDay{ID: burgerDayID}
-	res := r.DB.First(burgerDay)
-
-	if res.Error != nil {
-		return nil, res.Error
+	burgerDay, err := r.Repos.BurgerDays.Get(ctx, burgerDayID)
+	if err != nil {
+		return nil, notFound(err, "burger day", burgerDayID)
 	}
 
 	burgerDay.Closed = true
-	res = r.DB.Save(burgerDay)
-
-	if res.Error != nil {
-		return nil, res.Error
+	if err := r.Repos.BurgerDays.Save(ctx, burgerDay); err != nil {
+		return nil, conflict(err, "burger day", burgerDayID)
 	}
 
 	return persistence.BurgerDayToModel(burgerDay), nil
@@ -75,14 +87,14 @@ func (r *mutationResolver) CloseBurgerDay(ctx context.Context, burgerDayID strin
 // CreateUser is the resolver for the create_user field.
 func (r *mutationResolver) CreateUser(ctx context.Context, name string, email string) (*model.User, error) {
 	user := &persistence.User{
-		ID:    uuid.New().String(),
-		Name:  name,
-		Email: email,
+		ID:          uuid.New().String(),
+		Name:        name,
+		Email:       email,
+		PendingLink: true,
 	}
 
-	res := r.DB.Create(user)
-	if res.Error != nil {
-		return nil, res.Error
+	if err := r.Repos.Users.Create(ctx, user); err != nil {
+		return nil, err
 	}
 
 	return persistence.UserToModel(user), nil
@@ -97,63 +109,70 @@ func (r *mutationResolver) OrderBurger(ctx context.Context, burgerDayID string,
 		BurgerDayId:    burgerDayID,
 		SpecialRequest: persistence.SpecialOrdersToStrings(specialRequest),
 	}
-	burgerDay := &persistence.BurgerDay{ID: burgerDayID}
-	r.DB.First(burgerDay)
-	if burgerDay.Closed {
-		return nil, stats.ErrBurgerDayClosed{}
-	}
 
-	res := r.DB.Create(order)
-	if res.Error != nil {
-		return nil, res.Error
+	// Checked while the day is locked, so closing it concurrently cannot let the order in
+	err := r.Repos.Orders.CreateForBurgerDay(ctx, order, func(burgerDay *persistence.BurgerDay) error {
+		if burgerDay.Closed {
+			return stats.ErrBurgerDayClosed{}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, notFound(err, "burger day", burgerDayID)
 	}
 
 	return persistence.OrderToModel(order), nil
 }
 
-// DeleteBurger is the resolver for the deleteBurger mutation.
-// Only the owner of the burger day can delete ses
+// DeleteOrder is the resolver for the delete_order field.
+// Ownership is checked by the @isOwner directive before this runs
 func (r *mutationResolver) DeleteOrder(ctx context.Context, orderID string) (bool, error) {
-	userCtx := auth.ForContext(ctx)
+	if _, err := r.Repos.Orders.Get(ctx, orderID); err != nil {
+		return false, notFound(err, "order", orderID)
+	}
 
-	var order persistence.Order
-	if err := r.DB.First(&order, "id = ?", orderID).Error; err != nil {
-		return false, err
+	// Delete the order, admins can restore it until it is purged
+	if err := r.Repos.Orders.Delete(ctx, orderID, auth.ForContext(ctx).ID); err != nil {
+		return false, notFound(err, "order", orderID)
 	}
 
-	var burgerDay persistence.BurgerDay
-	if err := r.DB.First(&burgerDay, "id = ?", order.BurgerDayId).Error; err != nil {
-		return false, err
+	return true, nil
+}
+
+// PayOrder is the resolver for the pay_order field.
+func (r *mutationResolver) PayOrder(ctx context.Context, orderID string, userID string) (*model.Order, error) {
+	order, err := r.Repos.Orders.Get(ctx, orderID)
+	if err != nil {
+		return nil, notFound(err, "order", orderID)
 	}
 
-	// Check if the logged-in user is the author of the burger day or the owner of the order
-	if burgerDay.AuthorId != userCtx.ID && order.UserId != userCtx.ID {
-		return false, errors.New("only the owner of the burger day can delete orders")
+	if order.UserId != userID {
+		return nil, errors.New("order does not belong to the given user")
 	}
 
-	// Delete the order
-	if err := r.DB.Delete(&order).Error; err != nil {
-		return false, err
+	order, err = setOrderPaid(ctx, r.Repos.Orders, auth.ForContext(ctx), orderID, true)
+	if err != nil {
+		return nil, err
 	}
 
-	return true, nil
+	return persistence.OrderToModel(order), nil
 }
 
-
-// PayOrder is the resolver for the pay_order field.
-func (r *mutationResolver) PayOrder(ctx context.Context, orderID string, userID string) (*model.Order, error) {
-	order := &persistence.Order{ID: orderID}
-	res := r.DB.First(order)
-
-	if res.Error != nil {
-		return nil, res.Error
+// MarkPaid is the resolver for the markPaid field.
+func (r *mutationResolver) MarkPaid(ctx context.Context, orderID string) (*model.Order, error) {
+	order, err := setOrderPaid(ctx, r.Repos.Orders, auth.ForContext(ctx), orderID, true)
+	if err != nil {
+		return nil, err
 	}
 
-	order.Paid = !order.Paid
+	return persistence.OrderToModel(order), nil
+}
 
-	res = r.DB.Save(order)
-	if res.Error != nil {
-		return nil, res.Error
+// MarkUnpaid is the resolver for the markUnpaid field.
+func (r *mutationResolver) MarkUnpaid(ctx context.Context, orderID string) (*model.Order, error) {
+	order, err := setOrderPaid(ctx, r.Repos.Orders, auth.ForContext(ctx), orderID, false)
+	if err != nil {
+		return nil, err
 	}
 
 	return persistence.OrderToModel(order), nil
@@ -162,31 +181,41 @@ func (r *mutationResolver) PayOrder(ctx context.Context, orderID string, userID
 // StartBurgerDay is the resolver for the start_burger_day field.
 func (r *mutationResolver) StartBurgerDay(ctx context.Context) (*model.BurgerDay, error) {
 	user := auth.ForContext(ctx)
-	currentDateString := time.Now().Format("2006-01-02") // Format the date as a string in the format "YYYY-MM-DD"
+	today := office.Today() // Today in the office, the server may run in another timezone
+	noon, err := office.Noon(today)
+	if err != nil {
+		return nil, err
+	}
 
 	burgerDay := &persistence.BurgerDay{
-		ID:       uuid.New().String(),
-		AuthorId: user.ID,
-		Date:     currentDateString, // Set the date to the current date
+		ID:            uuid.New().String(),
+		AuthorId:      user.ID,
+		Date:          persistence.Date(today),
+		EstimatedTime: noon,
 	}
 
-	res := r.DB.Create(burgerDay)
-	if res.Error != nil {
-		return nil, res.Error
+	if err := r.Repos.BurgerDays.Create(ctx, burgerDay); err != nil {
+		return nil, err
 	}
 
 	return persistence.BurgerDayToModel(burgerDay), nil
 }
 
 // UpdateBurgerDay is the resolver for the update_burger_day field.
-func (r *mutationResolver) UpdateBurgerDay(ctx context.Context, burgerDayID string, estimatedTime *string, price *float64, closed *bool) (*model.BurgerDay, error) {
-	burgerDay := &persistence.BurgerDay{ID: burgerDayID}
-	res := r.DB.First(burgerDay)
-	if res.Error != nil {
-		return nil, res.Error
+func (r *mutationResolver) UpdateBurgerDay(ctx context.Context, burgerDayID string, estimatedTime *time.Time, price *float64, closed *bool, expectedVersion *int) (*model.BurgerDay, error) {
+	burgerDay, err := r.Repos.BurgerDays.Get(ctx, burgerDayID)
+	if err != nil {
+		return nil, notFound(err, "burger day", burgerDayID)
+	}
+	if err := checkVersion(expectedVersion, burgerDay.Version, "burger day", burgerDayID); err != nil {
+		return nil, err
 	}
 
 	if estimatedTime != nil {
+		// The burgers arrive on the day itself, as seen in the office
+		if estimatedTime.In(office.Location()).Format(office.DateLayout) != string(burgerDay.Date) {
+			return nil, fmt.Errorf("estimated time must be on the burger day, %s", burgerDay.Date)
+		}
 		burgerDay.EstimatedTime = *estimatedTime
 	}
 	if price != nil {
@@ -196,38 +225,38 @@ func (r *mutationResolver) UpdateBurgerDay(ctx context.Context, burgerDayID stri
 		burgerDay.Closed = *closed
 	}
 
-	res = r.DB.Save(burgerDay)
-	if res.Error != nil {
-		return nil, res.Error
+	// Also fails when someone else saved the day since it was read above
+	if err := r.Repos.BurgerDays.Save(ctx, burgerDay); err != nil {
+		return nil, conflict(err, "burger day", burgerDayID)
 	}
 
 	return persistence.BurgerDayToModel(burgerDay), nil
 }
 
-
 // UpdateUser is the resolver for the update_user field.
-func (r *mutationResolver) UpdateUser(ctx context.Context, name *string, email *string, phoneNumber *string) (*model.User, error) {
+func (r *mutationResolver) UpdateUser(ctx context.Context, name *string, phoneNumber *string, expectedVersion *int) (*model.User, error) {
 	userCtx := auth.ForContext(ctx)
-	user := &persistence.User{ID: userCtx.ID}
 
-	res := r.DB.First(user)
-	if res.Error != nil {
-		return nil, res.Error
+	user, err := r.Repos.Users.Get(ctx, userCtx.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkVersion(expectedVersion, user.Version, "user", user.ID); err != nil {
+		return nil, err
 	}
 
+	// The email is not editable, it comes from the identity provider or SCIM. A user editing
+	// their profile has claimed it, so it is no longer linked by email.
+	user.PendingLink = false
 	if name != nil {
 		user.Name = *name
 	}
-	if email != nil {
-		user.Email = *email
-	}
 	if phoneNumber != nil {
 		user.PhoneNumber = *phoneNumber
 	}
 
-	res = r.DB.Save(user)
-	if res.Error != nil {
-		return nil, res.Error
+	if err := r.Repos.Users.Save(ctx, user); err != nil {
+		return nil, conflict(err, "user", user.ID)
 	}
 
 	return persistence.UserToModel(user), nil
@@ -235,34 +264,205 @@ func (r *mutationResolver) UpdateUser(ctx context.Context, name *string, email *
 
 // DeleteBurgerDay is the resolver for the delete_burger_day field.
 func (r *mutationResolver) DeleteBurgerDay(ctx context.Context, burgerDayID string) (*string, error) {
-	u := auth.ForContext(ctx)
+	// Delete the burger day along with its orders, admins can restore them until they are purged
+	if err := r.Repos.BurgerDays.Delete(ctx, burgerDayID, auth.ForContext(ctx).ID); err != nil {
+		return nil, notFound(err, "burger day", burgerDayID)
+	}
 
-	// Create a slice of allowed emails
-	allowedEmails := []string{"simon.egeberg@twoday.com", "seg@it-minds.dk", "simon.bundgaard-egeberg@twoday.com", "katharina.vu@twoday.com"}
+	return &burgerDayID, nil
+}
 
-	// Check if the user's email is in the allowed list
-	emailAllowed := slices.Contains(allowedEmails, u.Email)
+// RestoreBurgerDay is the resolver for the restoreBurgerDay field.
+func (r *mutationResolver) RestoreBurgerDay(ctx context.Context, burgerDayID string) (*model.BurgerDay, error) {
+	burgerDay, err := r.Repos.BurgerDays.Restore(ctx, burgerDayID)
+	if err != nil {
+		return nil, notFound(err, "deleted burger day", burgerDayID)
+	}
 
-	// If email not allowed, return permission error
-	if !emailAllowed {
-		return nil, errors.New("permission denied: user not authorized to delete burger days")
+	return persistence.BurgerDayToModel(burgerDay), nil
+}
+
+// RestoreOrder is the resolver for the restoreOrder field.
+func (r *mutationResolver) RestoreOrder(ctx context.Context, orderID string) (*model.Order, error) {
+	order, err := r.Repos.Orders.Restore(ctx, orderID)
+	if err != nil {
+		return nil, notFound(err, "deleted order", orderID)
 	}
 
-	// Then delete it
-	res := r.DB.Delete(&persistence.BurgerDay{ID: burgerDayID})
-	if res.Error != nil {
-		return nil, res.Error
+	return persistence.OrderToModel(order), nil
+}
+
+// GrantRole is the resolver for the grantRole field.
+func (r *mutationResolver) GrantRole(ctx context.Context, userID string, role model.Role) (*model.User, error) {
+	user, err := r.Repos.Users.Get(ctx, userID)
+	if err != nil {
+		return nil, notFound(err, "user", userID)
 	}
 
-	return &burgerDayID, nil
+	// Admins may step down, as long as another admin is left to grant roles
+	user.Role = role
+	if err := r.Repos.Users.SaveRole(ctx, user); err != nil {
+		return nil, conflict(err, "user", userID)
+	}
+
+	return persistence.UserToModel(user), nil
+}
+
+// RevokeRole is the resolver for the revokeRole field.
+func (r *mutationResolver) RevokeRole(ctx context.Context, userID string) (*model.User, error) {
+	user, err := r.Repos.Users.Get(ctx, userID)
+	if err != nil {
+		return nil, notFound(err, "user", userID)
+	}
+
+	// Only elevated roles are revoked, guests stay guests rather than being promoted
+	if !auth.HasRole(user, model.RoleHost) {
+		return persistence.UserToModel(user), nil
+	}
+
+	// Like grantRole, this fails rather than leave nobody who can grant roles again
+	user.Role = model.RoleMember
+	if err := r.Repos.Users.SaveRole(ctx, user); err != nil {
+		return nil, conflict(err, "user", userID)
+	}
+
+	return persistence.UserToModel(user), nil
+}
+
+// RevokeSessions is the resolver for the revokeSessions field.
+func (r *mutationResolver) RevokeSessions(ctx context.Context, userID *string) (int, error) {
+	user := auth.ForContext(ctx)
+
+	targetID := user.ID
+	if userID != nil && *userID != user.ID {
+		if !auth.HasRole(user, model.RoleAdmin) {
+			return 0, errors.New("permission denied: only admins can revoke sessions of other users")
+		}
+		targetID = *userID
+	}
+
+	return r.Repos.Sessions.RevokeByUser(ctx, targetID)
+}
+
+// CreateAPIToken is the resolver for the createApiToken field.
+func (r *mutationResolver) CreateAPIToken(ctx context.Context, name string, scopes []model.TokenScope, expiresInDays *int, userID *string) (*model.CreatedAPIToken, error) {
+	user := auth.ForContext(ctx)
+
+	// A token would outlive the impersonation
+	if auth.ActorForContext(ctx) != nil {
+		return nil, errors.New("permission denied: cannot create API tokens while impersonating")
+	}
+
+	if userID != nil && *userID != user.ID {
+		if !auth.HasRole(user, model.RoleAdmin) {
+			return nil, errors.New("permission denied: only admins can create tokens for other users")
+		}
+		target, err := r.Repos.Users.Get(ctx, *userID)
+		if err != nil {
+			return nil, err
+		}
+		user = target
+	}
+
+	var lifetime time.Duration
+	if expiresInDays != nil {
+		if *expiresInDays <= 0 {
+			return nil, errors.New("expiresInDays must be positive")
+		}
+		lifetime = time.Duration(*expiresInDays) * 24 * time.Hour
+	}
+
+	apiToken, token, err := auth.CreateAPIToken(ctx, r.Repos.APITokens, user, name, scopes, lifetime)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.CreatedAPIToken{
+		APIToken: persistence.APITokenToModel(apiToken),
+		Token:    token,
+	}, nil
+}
+
+// RevokeAPIToken is the resolver for the revokeApiToken field.
+func (r *mutationResolver) RevokeAPIToken(ctx context.Context, id string) (bool, error) {
+	user := auth.ForContext(ctx)
+
+	apiToken, err := r.Repos.APITokens.Get(ctx, id)
+	if err != nil {
+		return false, notFound(err, "API token", id)
+	}
+
+	if apiToken.UserId != user.ID && !auth.HasRole(user, model.RoleAdmin) {
+		return false, errors.New("permission denied: only the owner can revoke this token")
+	}
+
+	if apiToken.RevokedAt != nil {
+		return true, nil
+	}
+
+	if err := r.Repos.APITokens.Revoke(ctx, id); err != nil {
+		return false, notFound(err, "API token", id)
+	}
+
+	return true, nil
+}
+
+// CreateServiceAccount is the resolver for the createServiceAccount field.
+func (r *mutationResolver) CreateServiceAccount(ctx context.Context, name string) (*model.User, error) {
+	id := uuid.New().String()
+	user := &persistence.User{
+		ID:             id,
+		Name:           name,
+		Email:          fmt.Sprintf("%s@service-accounts.invalid", id), // service accounts have no mailbox, .invalid never resolves
+		Role:           model.RoleMember,
+		ServiceAccount: true,
+	}
+
+	if err := r.Repos.Users.Create(ctx, user); err != nil {
+		return nil, err
+	}
+
+	return persistence.UserToModel(user), nil
+}
+
+// MergeUsers is the resolver for the mergeUsers field.
+func (r *mutationResolver) MergeUsers(ctx context.Context, sourceUserID string, targetUserID string) (*model.User, error) {
+	user, err := mergeUsers(ctx, r.Repos.Users, sourceUserID, targetUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return persistence.UserToModel(user), nil
+}
+
+// Impersonate is the resolver for the impersonate field.
+func (r *mutationResolver) Impersonate(ctx context.Context, userID string) (*model.Impersonation, error) {
+	if auth.ActorForContext(ctx) != nil {
+		return nil, errors.New("permission denied: cannot impersonate while impersonating")
+	}
+
+	target, err := r.Repos.Users.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	token, ttl, err := auth.Impersonate(ctx, r.Repos.Sessions, auth.ForContext(ctx), target)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.Impersonation{
+		AccessToken: token,
+		ExpiresIn:   int(ttl.Seconds()),
+		User:        persistence.UserToModel(target),
+	}, nil
 }
 
 // BurgerDay is the resolver for the burgerDay field.
 func (r *orderResolver) BurgerDay(ctx context.Context, obj *model.Order) (*model.BurgerDay, error) {
-	burgerDay := &persistence.BurgerDay{ID: obj.BurgerDayId}
-	res := r.DB.First(burgerDay)
-	if res.Error != nil {
-		return nil, res.Error
+	burgerDay, err := r.Repos.BurgerDays.Get(ctx, obj.BurgerDayId)
+	if err != nil {
+		return nil, err
 	}
 
 	return persistence.BurgerDayToModel(burgerDay), nil
@@ -270,10 +470,37 @@ func (r *orderResolver) BurgerDay(ctx context.Context, obj *model.Order) (*model
 
 // User is the resolver for the user field.
 func (r *orderResolver) User(ctx context.Context, obj *model.Order) (*model.User, error) {
-	user := &persistence.User{ID: obj.UserId}
-	res := r.DB.First(user)
-	if res.Error != nil {
-		return nil, res.Error
+	user, err := r.Repos.Users.Get(ctx, obj.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	return persistence.UserToModel(user), nil
+}
+
+// PaidBy is the resolver for the paidBy field.
+func (r *orderResolver) PaidBy(ctx context.Context, obj *model.Order) (*model.User, error) {
+	if obj.PaidById == nil {
+		return nil, nil
+	}
+
+	user, err := r.Repos.Users.Get(ctx, *obj.PaidById)
+	if err != nil {
+		return nil, err
+	}
+
+	return persistence.UserToModel(user), nil
+}
+
+// DeletedBy is the resolver for the deletedBy field.
+func (r *orderResolver) DeletedBy(ctx context.Context, obj *model.Order) (*model.User, error) {
+	if obj.DeletedById == nil {
+		return nil, nil
+	}
+
+	user, err := r.Repos.Users.Get(ctx, *obj.DeletedById)
+	if err != nil {
+		return nil, err
 	}
 
 	return persistence.UserToModel(user), nil
@@ -281,7 +508,7 @@ func (r *orderResolver) User(ctx context.Context, obj *model.Order) (*model.User
 
 // AccumulatedOrders is the resolver for the accumulated_orders field.
 func (r *queryResolver) AccumulatedOrders(ctx context.Context) (*model.AccumulatedOrders, error) {
-	bg_day, error := current_burger_day(r.DB)
+	bg_day, error := current_burger_day(ctx, r.Repos.BurgerDays)
 
 	if error != nil {
 		return nil, error
@@ -289,22 +516,25 @@ func (r *queryResolver) AccumulatedOrders(ctx context.Context) (*model.Accumulat
 	if bg_day == nil {
 		return nil, nil
 	}
-	var orders []*persistence.Order
-	if err := r.DB.Where("burger_day_id = ?", bg_day.ID).Find(&orders).Error; err != nil {
+	orders, err := r.Repos.Orders.ListByBurgerDay(ctx, bg_day.ID)
+	if err != nil {
 		return nil, err
 	}
 
 	// Map to count unique special order combinations
+	// Keep the requests themselves, splitting the key again would turn no requests into [""]
 	uniqueOrders := make(map[string]int)
+	specialRequests := make(map[string][]string)
 	for _, order := range orders {
 		key := strings.Join(order.SpecialRequest, ",")
 		uniqueOrders[key]++
+		specialRequests[key] = order.SpecialRequest
 	}
 
 	// Convert to AccumulatedOrderLine slice
 	var accumulatedOrderLines []*model.AccumulatedOrderLine
 	for specialReq, count := range uniqueOrders {
-		specialOrders, err := persistence.StringsToSpecialOrders(strings.Split(specialReq, ","))
+		specialOrders, err := persistence.StringsToSpecialOrders(specialRequests[specialReq])
 		if err != nil {
 			return nil, err
 		}
@@ -323,23 +553,29 @@ func (r *queryResolver) AccumulatedOrders(ctx context.Context) (*model.Accumulat
 
 // BurgerDay is the resolver for the burger_day field.
 func (r *queryResolver) BurgerDay(ctx context.Context, id string) (*model.BurgerDay, error) {
-	burgerDay := persistence.BurgerDay{}
-	res := r.DB.First(&burgerDay, id)
-
-	if res.Error != nil {
-		return nil, res.Error
+	burgerDay, err := r.Repos.BurgerDays.Get(ctx, id)
+	if err != nil {
+		return nil, notFound(err, "burger day", id)
 	}
 
-	return persistence.BurgerDayToModel(&burgerDay), nil
+	return persistence.BurgerDayToModel(burgerDay), nil
 }
 
 // BurgerDays is the resolver for the burger_days field.
 func (r *queryResolver) BurgerDays(ctx context.Context) ([]*model.BurgerDay, error) {
-	var burgerDays []*persistence.BurgerDay
-	res := r.DB.Find(&burgerDays)
+	burgerDays, err := r.Repos.BurgerDays.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return persistence.BurgerDaysToModels(burgerDays), nil
+}
 
-	if res.Error != nil {
-		return nil, res.Error
+// DeletedBurgerDays is the resolver for the deletedBurgerDays field.
+func (r *queryResolver) DeletedBurgerDays(ctx context.Context) ([]*model.BurgerDay, error) {
+	burgerDays, err := r.Repos.BurgerDays.ListDeleted(ctx)
+	if err != nil {
+		return nil, err
 	}
 
 	return persistence.BurgerDaysToModels(burgerDays), nil
@@ -347,32 +583,26 @@ func (r *queryResolver) BurgerDays(ctx context.Context) ([]*model.BurgerDay, err
 
 // BurgerStats is the resolver for the burgerStats field.
 func (r *queryResolver) BurgerStats(ctx context.Context) (*model.BurgerStats, error) {
-	res, err := stats.CalculateBurgerStats(r.DB)
+	res, err := stats.CalculateBurgerStats(ctx, r.Repos)
 
 	return stats.BurgerStatsToModel(res), err
 }
 
 // Order is the resolver for the order field.
 func (r *queryResolver) Order(ctx context.Context, id string) (*model.Order, error) {
-	order := persistence.Order{}
-	res := r.DB.First(&order, id)
-
-	if res.Error != nil {
-		return nil, res.Error
+	order, err := r.Repos.Orders.Get(ctx, id)
+	if err != nil {
+		return nil, notFound(err, "order", id)
 	}
 
-	return persistence.OrderToModel(&order), nil
+	return persistence.OrderToModel(order), nil
 }
 
 // Orders is the resolver for the orders field.
 func (r *queryResolver) Orders(ctx context.Context) ([]*model.Order, error) {
-	var orders []*persistence.Order
-
-	// preload user on order
-	res := r.DB.Find(&orders)
-
-	if res.Error != nil {
-		return nil, res.Error
+	orders, err := r.Repos.Orders.List(ctx)
+	if err != nil {
+		return nil, err
 	}
 
 	return persistence.OrdersToModels(orders), nil
@@ -380,14 +610,9 @@ func (r *queryResolver) Orders(ctx context.Context) ([]*model.Order, error) {
 
 // TodaysBurgers is the resolver for the todays_burgers field.
 func (r *queryResolver) TodaysBurgers(ctx context.Context) (*model.BurgerDay, error) {
-	currentDateString := time.Now().Format("2006-01-02") // Format the date as a string in the format "YYYY-MM-DD"
-	burgerDay := &persistence.BurgerDay{}
-	res := r.DB.Where("date = ?", currentDateString).First(burgerDay)
-	if res.Error != nil {
-		if errors.Is(res.Error, gorm.ErrRecordNotFound) {
-			return nil, nil // Return nil if no record is found for today
-		}
-		return nil, res.Error
+	burgerDay, err := current_burger_day(ctx, r.Repos.BurgerDays)
+	if err != nil || burgerDay == nil {
+		return nil, err
 	}
 
 	return persistence.BurgerDayToModel(burgerDay), nil
@@ -395,34 +620,59 @@ func (r *queryResolver) TodaysBurgers(ctx context.Context) (*model.BurgerDay, er
 
 // User is the resolver for the user field.
 func (r *queryResolver) User(ctx context.Context, id string) (*model.User, error) {
-	user := persistence.User{ID: id}
-	res := r.DB.First(&user)
-
-	if res.Error != nil {
-		return nil, res.Error
+	user, err := r.Repos.Users.Get(ctx, id)
+	if err != nil {
+		return nil, notFound(err, "user", id)
 	}
 
-	return persistence.UserToModel(&user), nil
+	return persistence.UserToModel(user), nil
 }
 
 // Users is the resolver for the users field.
 func (r *queryResolver) Users(ctx context.Context) ([]*model.User, error) {
-	var users []*persistence.User
-	res := r.DB.Find(&users)
-
-	if res.Error != nil {
-		return nil, res.Error
+	users, err := r.Repos.Users.ListActive(ctx)
+	if err != nil {
+		return nil, err
 	}
 
 	return persistence.UsersToModels(users), nil
 }
 
+// Impersonator is the resolver for the impersonator field.
+func (r *queryResolver) Impersonator(ctx context.Context) (*model.User, error) {
+	actor := auth.ActorForContext(ctx)
+	if actor == nil {
+		return nil, nil
+	}
+	return persistence.UserToModel(actor), nil
+}
+
 // Me is the resolver for the me field.
 func (r *queryResolver) Me(ctx context.Context) (*model.User, error) {
 	user := auth.ForContext(ctx)
 	return persistence.UserToModel(user), nil
 }
 
+// APITokens is the resolver for the apiTokens field.
+func (r *queryResolver) APITokens(ctx context.Context, userID *string) ([]*model.APIToken, error) {
+	user := auth.ForContext(ctx)
+
+	targetID := user.ID
+	if userID != nil && *userID != user.ID {
+		if !auth.HasRole(user, model.RoleAdmin) {
+			return nil, errors.New("permission denied: only admins can list tokens of other users")
+		}
+		targetID = *userID
+	}
+
+	tokens, err := r.Repos.APITokens.ListActive(ctx, targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	return persistence.APITokensToModels(tokens), nil
+}
+
 // RingBurgerBell is the resolver for the ringBurgerBell field.
 func (r *mutationResolver) RingBurgerBell(ctx context.Context, message string) (bool, error) {
 	// Create a new BurgerBellEvent with the message and current timestamp
@@ -440,16 +690,19 @@ func (r *mutationResolver) RingBurgerBell(ctx context.Context, message string) (
 func (r *subscriptionResolver) BurgerBell(ctx context.Context) (<-chan *model.BurgerBellEvent, error) {
 	// Register a new subscriber and get a unique channel
 	id, ch := r.RegisterSubscriber()
-	
+
 	// Set up cleanup when the context is done
 	go func() {
 		<-ctx.Done()
 		r.UnregisterSubscriber(id)
 	}()
-	
+
 	return ch, nil
 }
 
+// ApiToken returns ApiTokenResolver implementation.
+func (r *Resolver) ApiToken() ApiTokenResolver { return &apiTokenResolver{r} }
+
 // BurgerDay returns BurgerDayResolver implementation.
 func (r *Resolver) BurgerDay() BurgerDayResolver { return &burgerDayResolver{r} }
 
@@ -467,6 +720,7 @@ func (r *Resolver) Subscription() SubscriptionResolver {
 	return &subscriptionResolver{r}
 }
 
+type apiTokenResolver struct{ *Resolver }
 type burgerDayResolver struct{ *Resolver }
 type mutationResolver struct{ *Resolver }
 type orderResolver struct{ *Resolver }
@@ -0,0 +1,415 @@
+package graph
+
+import (
+	"context"
+	"graphql-go/graph/model"
+	"graphql-go/persistence"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/99designs/gqlgen/client"
+)
+
+func TestAPITokens(t *testing.T) {
+	repos := persistence.NewMemoryRepositories()
+	c := newTestClient(repos)
+
+	member := addUser(t, repos, "member", model.RoleMember)
+	other := addUser(t, repos, "other", model.RoleMember)
+
+	var created struct {
+		CreateAPIToken struct {
+			APIToken struct {
+				ID     string
+				Scopes []string
+			} `json:"apiToken"`
+			Token string
+		} `json:"createApiToken"`
+	}
+	err := c.Post(`mutation { createApiToken(name: "ci", scopes: [READ]) { apiToken { id scopes } token } }`, &created, as(member))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(created.CreateAPIToken.Token, "bgr_") {
+		t.Errorf("got token %q, want a bgr_ token", created.CreateAPIToken.Token)
+	}
+	id := created.CreateAPIToken.APIToken.ID
+
+	const list = `query { apiTokens { id } }`
+	var listed struct {
+		APITokens []struct{ ID string } `json:"apiTokens"`
+	}
+	c.MustPost(list, &listed, as(member))
+	if len(listed.APITokens) != 1 || listed.APITokens[0].ID != id {
+		t.Fatalf("listed %v, want the created token", listed.APITokens)
+	}
+
+	const revoke = `mutation($id: ID!) { revokeApiToken(id: $id) }`
+	var revoked map[string]interface{}
+	err = c.Post(revoke, &revoked, as(other), client.Var("id", id))
+	if message, _ := errorMessage(t, err); !strings.Contains(message, "permission denied") {
+		t.Errorf("others revoking the token: got %q, want permission denied", message)
+	}
+	err = c.Post(revoke, &revoked, as(member), client.Var("id", "missing"))
+	if _, code := errorMessage(t, err); code != "NOT_FOUND" {
+		t.Errorf("revoking an unknown token: got code %q, want NOT_FOUND", code)
+	}
+
+	c.MustPost(revoke, &revoked, as(member), client.Var("id", id))
+	listed.APITokens = nil
+	c.MustPost(list, &listed, as(member))
+	if len(listed.APITokens) != 0 {
+		t.Errorf("listed %v after revoking, want none", listed.APITokens)
+	}
+}
+
+func TestRevokeSessions(t *testing.T) {
+	repos := persistence.NewMemoryRepositories()
+	c := newTestClient(repos)
+
+	member := addUser(t, repos, "member", model.RoleMember)
+	admin := addUser(t, repos, "admin", model.RoleAdmin)
+	for _, id := range []string{"phone", "laptop"} {
+		session := &persistence.Session{ID: id, UserId: member.ID, RefreshTokenHash: id, ExpiresAt: time.Now().Add(time.Hour)}
+		if err := repos.Sessions.Create(context.Background(), session); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	const revoke = `mutation($userId: ID) { revokeSessions(userId: $userId) }`
+	var resp struct {
+		RevokeSessions int `json:"revokeSessions"`
+	}
+	err := c.Post(revoke, &resp, as(member), client.Var("userId", admin.ID))
+	if message, _ := errorMessage(t, err); !strings.Contains(message, "permission denied") {
+		t.Errorf("members revoking sessions of others: got %q, want permission denied", message)
+	}
+
+	c.MustPost(revoke, &resp, as(admin), client.Var("userId", member.ID))
+	if resp.RevokeSessions != 2 {
+		t.Errorf("revoked %d sessions, want 2", resp.RevokeSessions)
+	}
+	c.MustPost(revoke, &resp, as(member), client.Var("userId", nil))
+	if resp.RevokeSessions != 0 {
+		t.Errorf("revoked %d sessions again, want 0", resp.RevokeSessions)
+	}
+}
+
+func TestMergeUsers(t *testing.T) {
+	repos := persistence.NewMemoryRepositories()
+	c := newTestClient(repos)
+	ctx := context.Background()
+
+	admin := addUser(t, repos, "admin", model.RoleAdmin)
+	source := addUser(t, repos, "source", model.RoleHost)
+	source.PhoneNumber = "+45 12345678"
+	source.Teams = persistence.StringArray{"platform"}
+	if err := repos.Users.Save(ctx, source); err != nil {
+		t.Fatal(err)
+	}
+	target := addUser(t, repos, "target", model.RoleMember)
+	burgerDay := addBurgerDay(t, repos, "day", "2024-03-21", source)
+	addOrder(t, repos, "order", burgerDay, source)
+	apiToken := &persistence.APIToken{ID: "token", UserId: source.ID, TokenHash: "hash"}
+	if err := repos.APITokens.Create(ctx, apiToken); err != nil {
+		t.Fatal(err)
+	}
+
+	const merge = `mutation($source: ID!, $target: ID!) { mergeUsers(sourceUserId: $source, targetUserId: $target) { id role phoneNumber teams } }`
+	var resp struct {
+		MergeUsers struct {
+			ID          string
+			Role        string
+			PhoneNumber string `json:"phoneNumber"`
+			Teams       []string
+		} `json:"mergeUsers"`
+	}
+	err := c.Post(merge, &resp, as(admin), client.Var("source", source.ID), client.Var("target", source.ID))
+	if message, _ := errorMessage(t, err); !strings.Contains(message, "into itself") {
+		t.Errorf("merging a user into itself: got %q", message)
+	}
+	err = c.Post(merge, &resp, as(admin), client.Var("source", "missing"), client.Var("target", target.ID))
+	if _, code := errorMessage(t, err); code != "NOT_FOUND" {
+		t.Errorf("merging an unknown user: got code %q, want NOT_FOUND", code)
+	}
+
+	c.MustPost(merge, &resp, as(admin), client.Var("source", source.ID), client.Var("target", target.ID))
+	merged := resp.MergeUsers
+	if merged.ID != target.ID || merged.Role != string(model.RoleHost) || merged.PhoneNumber != source.PhoneNumber {
+		t.Errorf("got %+v, want the target with the role and phone number of the source", merged)
+	}
+	if len(merged.Teams) != 1 || merged.Teams[0] != "platform" {
+		t.Errorf("got teams %v, want the teams of the source", merged.Teams)
+	}
+
+	if _, err := repos.Users.Get(ctx, source.ID); err != persistence.ErrNotFound {
+		t.Errorf("source still exists: %v", err)
+	}
+	if day, _ := repos.BurgerDays.Get(ctx, burgerDay.ID); day.AuthorId != target.ID || day.Version != burgerDay.Version+1 {
+		t.Errorf("burger day has author %s and version %d, want the target and a new version", day.AuthorId, day.Version)
+	}
+	if order, _ := repos.Orders.Get(ctx, "order"); order.UserId != target.ID {
+		t.Errorf("order belongs to %s, want the target", order.UserId)
+	}
+	if token, _ := repos.APITokens.Get(ctx, apiToken.ID); token.UserId != target.ID {
+		t.Errorf("API token belongs to %s, want the target", token.UserId)
+	}
+}
+
+func TestBurgerStats(t *testing.T) {
+	repos := persistence.NewMemoryRepositories()
+	c := newTestClient(repos)
+
+	host := addUser(t, repos, "host", model.RoleHost)
+	member := addUser(t, repos, "member", model.RoleMember)
+	monday := addBurgerDay(t, repos, "monday", "2024-03-18", host)
+	friday := addBurgerDay(t, repos, "friday", "2024-03-22", host)
+	addOrder(t, repos, "host-monday", monday, host)
+	addOrder(t, repos, "member-monday", monday, member)
+	addOrder(t, repos, "member-friday", friday, member)
+	addOrder(t, repos, "member-friday-2", friday, member)
+	addOrder(t, repos, "deleted", friday, host)
+	if err := repos.Orders.Delete(context.Background(), "deleted", host.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	var resp struct {
+		BurgerStats struct {
+			TotalOrders     int `json:"totalOrders"`
+			TotalBurgerDays int `json:"totalBurgerDays"`
+			TopConsumers    []struct {
+				User struct {
+					ID string
+				}
+				TotalOrders     int `json:"totalOrders"`
+				TotalBurgerDays int `json:"totalBurgerDays"`
+			} `json:"topConsumers"`
+		} `json:"burgerStats"`
+	}
+	c.MustPost(`query { burgerStats { totalOrders totalBurgerDays topConsumers { user { id } totalOrders totalBurgerDays } } }`, &resp, as(member))
+
+	stats := resp.BurgerStats
+	if stats.TotalOrders != 4 || stats.TotalBurgerDays != 2 {
+		t.Errorf("got %d orders on %d days, want 4 on 2", stats.TotalOrders, stats.TotalBurgerDays)
+	}
+	want := map[string][2]int{"host": {1, 1}, "member": {3, 2}}
+	if len(stats.TopConsumers) != len(want) {
+		t.Fatalf("got %d consumers, want %d", len(stats.TopConsumers), len(want))
+	}
+	for _, consumer := range stats.TopConsumers {
+		if got := [2]int{consumer.TotalOrders, consumer.TotalBurgerDays}; got != want[consumer.User.ID] {
+			t.Errorf("%s: got %v orders and days, want %v", consumer.User.ID, got, want[consumer.User.ID])
+		}
+	}
+}
+
+func TestGrantRole(t *testing.T) {
+	repos := persistence.NewMemoryRepositories()
+	c := newTestClient(repos)
+
+	admin := addUser(t, repos, "admin", model.RoleAdmin)
+	member := addUser(t, repos, "member", model.RoleMember)
+
+	const grant = `mutation($userId: ID!, $role: Role!) { grantRole(userId: $userId, role: $role) { role version } }`
+	var resp struct {
+		GrantRole struct {
+			Role    string
+			Version int
+		} `json:"grantRole"`
+	}
+	err := c.Post(grant, &resp, as(member), client.Var("userId", member.ID), client.Var("role", "ADMIN"))
+	if message, _ := errorMessage(t, err); !strings.Contains(message, "permission denied") {
+		t.Errorf("members granting themselves a role: got %q, want permission denied", message)
+	}
+
+	c.MustPost(grant, &resp, as(admin), client.Var("userId", member.ID), client.Var("role", "HOST"))
+	if resp.GrantRole.Role != string(model.RoleHost) || resp.GrantRole.Version != member.Version+1 {
+		t.Errorf("got %+v, want a host at a new version", resp.GrantRole)
+	}
+
+	// The only admin cannot step down, once there is another one they can
+	err = c.Post(grant, &resp, as(admin), client.Var("userId", admin.ID), client.Var("role", "MEMBER"))
+	if message, _ := errorMessage(t, err); !strings.Contains(message, "last admin") {
+		t.Errorf("the last admin demoting themselves: got %q", message)
+	}
+	c.MustPost(grant, &resp, as(admin), client.Var("userId", member.ID), client.Var("role", "ADMIN"))
+	c.MustPost(grant, &resp, as(admin), client.Var("userId", admin.ID), client.Var("role", "MEMBER"))
+	if stored, _ := repos.Users.Get(context.Background(), admin.ID); stored.Role != model.RoleMember {
+		t.Errorf("got role %s after stepping down, want MEMBER", stored.Role)
+	}
+
+	member, _ = repos.Users.Get(context.Background(), member.ID)
+	err = c.Post(grant, &resp, as(member), client.Var("userId", "missing"), client.Var("role", "HOST"))
+	if _, code := errorMessage(t, err); code != "NOT_FOUND" {
+		t.Errorf("granting a role to an unknown user: got code %q, want NOT_FOUND", code)
+	}
+}
+
+func TestRevokeRole(t *testing.T) {
+	repos := persistence.NewMemoryRepositories()
+	c := newTestClient(repos)
+	ctx := context.Background()
+
+	admin := addUser(t, repos, "admin", model.RoleAdmin)
+	other := addUser(t, repos, "other", model.RoleAdmin)
+	host := addUser(t, repos, "host", model.RoleHost)
+	guest := addUser(t, repos, "guest", model.RoleGuest)
+
+	const revoke = `mutation($userId: ID!) { revokeRole(userId: $userId) { role } }`
+	var resp struct {
+		RevokeRole struct{ Role string } `json:"revokeRole"`
+	}
+	err := c.Post(revoke, &resp, as(host), client.Var("userId", guest.ID))
+	if message, _ := errorMessage(t, err); !strings.Contains(message, "permission denied") {
+		t.Errorf("hosts revoking roles: got %q, want permission denied", message)
+	}
+
+	tests := []struct {
+		user *persistence.User
+		want model.Role
+	}{
+		{host, model.RoleMember},
+		// Guests are not promoted to members
+		{guest, model.RoleGuest},
+		{other, model.RoleMember},
+	}
+	for _, test := range tests {
+		c.MustPost(revoke, &resp, as(admin), client.Var("userId", test.user.ID))
+		if resp.RevokeRole.Role != string(test.want) {
+			t.Errorf("%s: got role %s, want %s", test.user.ID, resp.RevokeRole.Role, test.want)
+		}
+	}
+
+	err = c.Post(revoke, &resp, as(admin), client.Var("userId", admin.ID))
+	if message, _ := errorMessage(t, err); !strings.Contains(message, "last admin") {
+		t.Errorf("revoking the role of the last admin: got %q", message)
+	}
+
+	// Deactivated admins cannot log in, so they do not count
+	other, _ = repos.Users.Get(ctx, other.ID)
+	other.Role = model.RoleAdmin
+	now := time.Now()
+	other.DeactivatedAt = &now
+	if err := repos.Users.Save(ctx, other); err != nil {
+		t.Fatal(err)
+	}
+	err = c.Post(revoke, &resp, as(admin), client.Var("userId", admin.ID))
+	if message, _ := errorMessage(t, err); !strings.Contains(message, "last admin") {
+		t.Errorf("revoking the role of the last active admin: got %q", message)
+	}
+
+	err = c.Post(revoke, &resp, as(admin), client.Var("userId", "missing"))
+	if _, code := errorMessage(t, err); code != "NOT_FOUND" {
+		t.Errorf("revoking the role of an unknown user: got code %q, want NOT_FOUND", code)
+	}
+}
+
+func TestQueryNotFound(t *testing.T) {
+	repos := persistence.NewMemoryRepositories()
+	c := newTestClient(repos)
+
+	admin := addUser(t, repos, "admin", model.RoleAdmin)
+	burgerDay := addBurgerDay(t, repos, "day", "2024-03-21", admin)
+	addOrder(t, repos, "order", burgerDay, admin)
+	if err := repos.Orders.Delete(context.Background(), "order", admin.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, query := range []string{
+		`query { order(id: "missing") { id } }`,
+		`query { order(id: "order") { id } }`,
+		`query { user(id: "missing") { id } }`,
+		`mutation { delete_order(orderId: "order") }`,
+	} {
+		var resp map[string]interface{}
+		err := c.Post(query, &resp, as(admin))
+		if err == nil {
+			t.Errorf("%s: succeeded", query)
+			continue
+		}
+		if _, code := errorMessage(t, err); code != "NOT_FOUND" {
+			t.Errorf("%s: got code %q, want NOT_FOUND", query, code)
+		}
+	}
+}
+
+func TestOrderBurgerClosed(t *testing.T) {
+	repos := persistence.NewMemoryRepositories()
+	c := newTestClient(repos)
+
+	host := addUser(t, repos, "host", model.RoleHost)
+	members := []*persistence.User{addUser(t, repos, "first", model.RoleMember), addUser(t, repos, "second", model.RoleMember)}
+	addBurgerDay(t, repos, "day", "2024-03-21", host)
+
+	const order = `mutation { orderBurger(burgerDayId: "day", specialRequest: [no_cheese]) { id } }`
+	var resp map[string]interface{}
+	c.MustPost(order, &resp, as(members[0]))
+	c.MustPost(`mutation { close_burger_day(burgerDayId: "day") { closed } }`, &resp, as(host))
+
+	// Both orders racing the closed day are turned away
+	errs := make(chan error)
+	for _, member := range members {
+		go func(member *persistence.User) {
+			var resp map[string]interface{}
+			errs <- c.Post(order, &resp, as(member))
+		}(member)
+	}
+	for range members {
+		err := <-errs
+		if message, _ := errorMessage(t, err); !strings.Contains(message, "burger day is closed") {
+			t.Errorf("ordering on a closed day: got %q, want it closed", message)
+		}
+	}
+	if count, _ := repos.Orders.CountByBurgerDay(context.Background(), "day"); count != 1 {
+		t.Errorf("got %d orders, want only the one placed before closing", count)
+	}
+}
+
+func TestExpectedVersion(t *testing.T) {
+	repos := persistence.NewMemoryRepositories()
+	c := newTestClient(repos)
+
+	host := addUser(t, repos, "host", model.RoleHost)
+	addBurgerDay(t, repos, "day", "2024-03-21", host)
+
+	var day struct {
+		UpdateBurgerDay struct {
+			Price   float64
+			Version int
+		} `json:"update_burger_day"`
+	}
+	c.MustPost(`mutation { update_burger_day(burgerDayId: "day", price: 95, expectedVersion: 1) { price version } }`, &day, as(host))
+	if day.UpdateBurgerDay.Version != 2 {
+		t.Errorf("got version %d after updating, want 2", day.UpdateBurgerDay.Version)
+	}
+
+	var user struct {
+		UpdateUser struct {
+			Name    string
+			Version int
+		} `json:"update_user"`
+	}
+	c.MustPost(`mutation { update_user(name: "Host", expectedVersion: 1) { name version } }`, &user, as(host))
+	if user.UpdateUser.Version != 2 {
+		t.Errorf("got user version %d after updating, want 2", user.UpdateUser.Version)
+	}
+
+	// Edits of the copies read before those updates are refused and change nothing
+	for _, mutation := range []string{
+		`mutation { update_burger_day(burgerDayId: "day", price: 120, expectedVersion: 1) { version } }`,
+		`mutation { update_user(name: "Stale", expectedVersion: 1) { version } }`,
+	} {
+		var resp map[string]interface{}
+		err := c.Post(mutation, &resp, as(host))
+		if _, code := errorMessage(t, err); code != "CONFLICT" {
+			t.Errorf("%s: got code %q, want CONFLICT", mutation, code)
+		}
+	}
+	if stored, _ := repos.BurgerDays.Get(context.Background(), "day"); stored.Price != 95 || stored.Version != 2 {
+		t.Errorf("got price %v at version %d, want the first update kept", stored.Price, stored.Version)
+	}
+	if stored, _ := repos.Users.Get(context.Background(), host.ID); stored.Name != "Host" || stored.Version != 2 {
+		t.Errorf("got name %q at version %d, want the first update kept", stored.Name, stored.Version)
+	}
+}
@@ -1,10 +1,14 @@
 package graph
 
 import (
+	"context"
 	"errors"
+	"graphql-go/auth"
+	"graphql-go/core/office"
+	"graphql-go/core/stats"
 	"graphql-go/persistence"
+	"slices"
 	"time"
-	"gorm.io/gorm"
 )
 
 // FindFirst returns the first element in the slice that matches the predicate function.
@@ -29,15 +33,109 @@ func LastElement[T any](s []T) (T, bool) {
 	return s[len(s)-1], true
 }
 
-func current_burger_day(r *gorm.DB) (*persistence.BurgerDay, error) {
-	currentDateString := time.Now().Format("2006-01-02") // Format the date as a string in the format "YYYY-MM-DD"
-	burgerDay := &persistence.BurgerDay{}
-	res := r.Where("date = ?", currentDateString).First(burgerDay)
-	if res.Error != nil {
-		if errors.Is(res.Error, gorm.ErrRecordNotFound) {
+// notFound turns a missing row into the error clients get with the code NOT_FOUND
+func notFound(err error, resource string, id string) error {
+	if errors.Is(err, persistence.ErrNotFound) {
+		return stats.ErrNotFound{Resource: resource, ID: id}
+	}
+	return err
+}
+
+// checkVersion fails with the error clients get with the code CONFLICT when they expect another
+// version than the stored one, i.e. they edited a stale copy
+func checkVersion(expected *int, version int, resource string, id string) error {
+	if expected != nil && *expected != version {
+		return stats.ErrConflict{Resource: resource, ID: id}
+	}
+	return nil
+}
+
+// conflict turns a save that lost against a concurrent one into the error clients get with the
+// code CONFLICT
+func conflict(err error, resource string, id string) error {
+	if errors.Is(err, persistence.ErrConflict) {
+		return stats.ErrConflict{Resource: resource, ID: id}
+	}
+	return err
+}
+
+// setOrderPaid marks the order as paid or unpaid on behalf of the user. Marking an order that
+// is already in the requested state is a no-op, so repeated clicks never revert a payment.
+func setOrderPaid(ctx context.Context, orders persistence.OrderRepo, user *persistence.User, orderID string, paid bool) (*persistence.Order, error) {
+	order, err := orders.Get(ctx, orderID)
+	if err != nil {
+		return nil, notFound(err, "order", orderID)
+	}
+
+	if order.Paid == paid {
+		return order, nil
+	}
+
+	order.Paid = paid
+	if paid {
+		now := time.Now()
+		order.PaidAt = &now
+		order.PaidById = &user.ID
+	} else {
+		order.PaidAt = nil
+		order.PaidById = nil
+	}
+
+	if err := orders.Save(ctx, order); err != nil {
+		return nil, notFound(err, "order", orderID)
+	}
+
+	return order, nil
+}
+
+// mergeUsers moves everything of the duplicate source user to the target user and deletes the
+// source. Provider accounts and API tokens move along, so the person logs in to and acts as the
+// target from then on. Sessions of the source are ended instead.
+func mergeUsers(ctx context.Context, users persistence.UserRepo, sourceID string, targetID string) (*persistence.User, error) {
+	if sourceID == targetID {
+		return nil, errors.New("cannot merge a user into itself")
+	}
+
+	source, err := users.Get(ctx, sourceID)
+	if err != nil {
+		return nil, notFound(err, "user", sourceID)
+	}
+	target, err := users.Get(ctx, targetID)
+	if err != nil {
+		return nil, notFound(err, "user", targetID)
+	}
+	if source.ServiceAccount || target.ServiceAccount {
+		return nil, errors.New("service accounts cannot be merged")
+	}
+
+	// The target keeps its own profile, only filling in what it lacks
+	if target.PhoneNumber == "" {
+		target.PhoneNumber = source.PhoneNumber
+	}
+	if !auth.HasRole(target, source.Role) {
+		target.Role = source.Role
+	}
+	for _, team := range source.Teams {
+		if !slices.Contains(target.Teams, team) {
+			target.Teams = append(target.Teams, team)
+		}
+	}
+
+	if err := users.Merge(ctx, source, target); err != nil {
+		return nil, conflict(err, "user", targetID)
+	}
+
+	return target, nil
+}
+
+func current_burger_day(ctx context.Context, burgerDays persistence.BurgerDayRepo) (*persistence.BurgerDay, error) {
+	// Today in the office, so the day does not roll over at midnight in the server's timezone
+	burgerDay, err := burgerDays.GetByDate(ctx, office.Today())
+	if err != nil {
+		if errors.Is(err, persistence.ErrNotFound) {
 			return nil, nil // Return nil if no record is found for today
 		}
-		return nil, res.Error
+		return nil, err
 	}
 
 	return burgerDay, nil
@@ -0,0 +1,158 @@
+package mailer
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"strings"
+	"testing"
+)
+
+// smtpSink accepts mail on a local port and hands the data of every message to the channel.
+// It speaks just enough SMTP for net/smtp, without STARTTLS or AUTH.
+func smtpSink(t *testing.T) (string, <-chan string) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	messages := make(chan string, 1)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveSMTP(conn, messages)
+		}
+	}()
+	return listener.Addr().String(), messages
+}
+
+func serveSMTP(conn net.Conn, messages chan<- string) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	reply := func(line string) { io.WriteString(conn, line+"\r\n") }
+
+	reply("220 localhost ESMTP sink")
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		command := strings.ToUpper(strings.TrimSpace(line))
+		switch {
+		case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+			reply("250 localhost")
+		case command == "DATA":
+			reply("354 end data with <CR><LF>.<CR><LF>")
+			var data strings.Builder
+			for {
+				line, err := reader.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if line == ".\r\n" {
+					break
+				}
+				// Undo the dot stuffing of lines starting with a dot
+				data.WriteString(strings.TrimPrefix(line, "."))
+			}
+			messages <- data.String()
+			reply("250 queued")
+		case command == "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("250 ok")
+		}
+	}
+}
+
+func TestSMTPSenderSendsHeadersAndBody(t *testing.T) {
+	addr, messages := smtpSink(t)
+	sender := &SMTPSender{Addr: addr, From: "burgers@example.com"}
+
+	// A login link is a single line longer than SMTP allows
+	link := "https://burger.example.com/auth/magic-link/verify?token=" + strings.Repeat("eyJhbGciOiJFUzI1NiJ9.", 60)
+	body := "Open this link to log in:\n\n" + link + "\n\n.a line starting with a dot\n"
+	err := sender.Send(context.Background(), Message{
+		To:      "guest@example.com",
+		Subject: "Your burger login link 🍔",
+		Body:    body,
+	})
+	if err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+
+	data := <-messages
+	for _, line := range strings.Split(data, "\r\n") {
+		if len(line) > 998 {
+			t.Fatalf("line of %d characters exceeds the SMTP limit", len(line))
+		}
+	}
+
+	msg, err := mail.ReadMessage(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("message does not parse: %v", err)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	headers := map[string]string{
+		"From":                      "burgers@example.com",
+		"To":                        "guest@example.com",
+		"Subject":                   "Your burger login link 🍔",
+		"MIME-Version":              "1.0",
+		"Content-Type":              "text/plain; charset=utf-8",
+		"Content-Transfer-Encoding": "quoted-printable",
+	}
+	for name, want := range headers {
+		got := msg.Header.Get(name)
+		if name == "Subject" {
+			got = subject
+		}
+		if got != want {
+			t.Errorf("header %s = %q, want %q", name, got, want)
+		}
+	}
+	if _, err := msg.Header.Date(); err != nil {
+		t.Errorf("invalid Date header: %v", err)
+	}
+
+	decoded, err := io.ReadAll(quotedprintable.NewReader(msg.Body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.ReplaceAll(string(decoded), "\r\n", "\n"); got != body {
+		t.Errorf("body = %q, want %q", got, body)
+	}
+}
+
+func TestSMTPSenderRejectsHeaderInjection(t *testing.T) {
+	addr, messages := smtpSink(t)
+	sender := &SMTPSender{Addr: addr, From: "burgers@example.com"}
+
+	for _, msg := range []Message{
+		{To: "guest@example.com\r\nBcc: everyone@example.com", Subject: "Hi", Body: "Hello"},
+		{To: "guest@example.com", Subject: "Hi\nBcc: everyone@example.com", Body: "Hello"},
+	} {
+		if err := sender.Send(context.Background(), msg); err == nil {
+			t.Errorf("Send(%q, %q) succeeded, want an error", msg.To, msg.Subject)
+		}
+	}
+
+	select {
+	case data := <-messages:
+		t.Fatalf("a message was sent: %q", data)
+	default:
+	}
+}
@@ -0,0 +1,43 @@
+package persistence
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"graphql-go/core/office"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// Date is a calendar day formatted as YYYY-MM-DD. Postgres stores it in a date column, which
+// the driver reads back as midnight UTC. SQLite has no date type and stores the text.
+type Date string
+
+// Value writes the date as text, which Postgres casts to the date column
+func (d Date) Value() (driver.Value, error) {
+	return string(d), nil
+}
+
+// Scan reads the date from a Postgres date or SQLite text
+func (d *Date) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case time.Time:
+		*d = Date(src.Format(office.DateLayout))
+	case string:
+		*d = Date(src)
+	case []byte:
+		*d = Date(src)
+	default:
+		return fmt.Errorf("cannot scan %T into Date", src)
+	}
+	return nil
+}
+
+// GormDBDataType stores dates in date columns on Postgres and as text elsewhere
+func (Date) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	if db.Dialector.Name() == "postgres" {
+		return "date"
+	}
+	return "text"
+}
@@ -1,71 +1,257 @@
 package persistence
 
 import (
-	"database/sql/driver"
+	"context"
+	"database/sql"
 	"fmt"
+	"github.com/glebarez/sqlite"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"graphql-go/graph/model"
+	"log"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 )
 
-// Assuming the model structs are as defined in your question.
-// Note: For GORM, you need to adjust struct tags slightly to match GORM's expectations.
+// sqlitePragmas are set on every SQLite connection. Foreign keys are off by default, and the
+// busy timeout makes writers wait for each other instead of failing.
+const sqlitePragmas = "_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)"
 
-type StringArray []string
+// openDialector picks the driver from the scheme of the DSN. postgres:// and postgresql://
+// URLs as well as key=value DSNs connect to Postgres, sqlite:<path> opens a SQLite file, or an
+// in-memory database with sqlite::memory:.
+func openDialector(dsn string) (gorm.Dialector, error) {
+	if path, ok := strings.CutPrefix(dsn, "sqlite:"); ok {
+		path = strings.TrimPrefix(path, "//")
+		if path == "" {
+			return nil, fmt.Errorf("sqlite DSN needs a path, e.g. sqlite:gogql.db")
+		}
+		separator := "?"
+		if strings.Contains(path, "?") {
+			separator = "&"
+		}
+		return sqlite.Open(path + separator + sqlitePragmas), nil
+	}
 
-func (a StringArray) Value() (driver.Value, error) {
-	if len(a) == 0 {
-		return "{}", nil
+	if strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://") || !strings.Contains(dsn, "://") {
+		return postgres.Open(dsn), nil
 	}
 
-	str := fmt.Sprintf("{%s}", strings.Join(a, ","))
-	return str, nil
+	scheme, _, _ := strings.Cut(dsn, "://")
+	return nil, fmt.Errorf("unsupported database scheme: %s", scheme)
 }
 
-func (a *StringArray) Scan(src interface{}) error {
-	str, ok := src.(string)
-	if !ok {
-		return fmt.Errorf("Failed to unmarshal StringArray: %s", src)
+const (
+	defaultConnectTimeout = time.Minute
+	firstConnectBackoff   = 500 * time.Millisecond
+	maxConnectBackoff     = 10 * time.Second
+)
+
+// poolConfig sizes the connection pool, zero values keep the database/sql defaults
+type poolConfig struct {
+	maxOpenConns    int
+	maxIdleConns    int // -1 keeps the default of 2, 0 keeps no idle connections
+	connMaxLifetime time.Duration
+	connMaxIdleTime time.Duration
+}
+
+// poolConfigFromEnv reads DB_MAX_OPEN_CONNS, DB_MAX_IDLE_CONNS, DB_CONN_MAX_LIFETIME and
+// DB_CONN_MAX_IDLE_TIME, e.g. 25, 5, 30m and 5m
+func poolConfigFromEnv() (poolConfig, error) {
+	pool := poolConfig{maxIdleConns: -1}
+	if err := parseIntEnv("DB_MAX_OPEN_CONNS", &pool.maxOpenConns); err != nil {
+		return pool, err
+	}
+	if err := parseIntEnv("DB_MAX_IDLE_CONNS", &pool.maxIdleConns); err != nil {
+		return pool, err
+	}
+	if err := parseDurationEnv("DB_CONN_MAX_LIFETIME", &pool.connMaxLifetime); err != nil {
+		return pool, err
 	}
+	if err := parseDurationEnv("DB_CONN_MAX_IDLE_TIME", &pool.connMaxIdleTime); err != nil {
+		return pool, err
+	}
+	return pool, nil
+}
 
-	str = strings.Trim(str, "{}")
-	*a = strings.Split(str, ",")
+func (p poolConfig) apply(sqlDB *sql.DB) {
+	sqlDB.SetMaxOpenConns(p.maxOpenConns)
+	if p.maxIdleConns >= 0 {
+		sqlDB.SetMaxIdleConns(p.maxIdleConns)
+	}
+	sqlDB.SetConnMaxLifetime(p.connMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(p.connMaxIdleTime)
+}
+
+// parseIntEnv sets value from the environment variable if it is set
+func parseIntEnv(key string, value *int) error {
+	s := os.Getenv(key)
+	if s == "" {
+		return nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 {
+		return fmt.Errorf("invalid %s: %s", key, s)
+	}
+	*value = n
+	return nil
+}
 
+// parseDurationEnv sets value from the environment variable if it is set
+func parseDurationEnv(key string, value *time.Duration) error {
+	s := os.Getenv(key)
+	if s == "" {
+		return nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return fmt.Errorf("invalid %s: %s", key, s)
+	}
+	*value = d
 	return nil
 }
-func ConnectGORM() *gorm.DB {
+
+// ConnectGORM connects to the database in DB_URL and checks that it answers. A database that
+// is not up yet, like a Postgres container starting next to ours, is retried with exponential
+// backoff for up to DB_CONNECT_TIMEOUT, a minute by default.
+func ConnectGORM(ctx context.Context) (*gorm.DB, error) {
 	// Check if the DB_URL environment variable is set.
 	dsn := os.Getenv("DB_URL")
 	if dsn == "" {
-		// Use hardcoded values for local development.
-		dsn = "host=localhost user=postgres password=changeme dbname=gogql"
+		// Use a SQLite file for local development, so no database server is needed.
+		dsn = "sqlite:gogql.db"
+	}
+
+	// A DSN or pool configuration that cannot work is not worth retrying
+	if _, err := openDialector(dsn); err != nil {
+		return nil, err
+	}
+	pool, err := poolConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	// Every connection to an in-memory database gets a database of its own
+	if strings.Contains(dsn, ":memory:") {
+		pool.maxOpenConns = 1
+	}
+
+	connectTimeout := defaultConnectTimeout
+	if err := parseDurationEnv("DB_CONNECT_TIMEOUT", &connectTimeout); err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, connectTimeout)
+	defer cancel()
+
+	backoff := firstConnectBackoff
+	for {
+		db, err := connect(ctx, dsn, pool)
+		if err == nil {
+			return db, nil
+		}
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("failed to connect to database: %w", err)
+		}
+
+		log.Printf("database not reachable, retrying in %s: %v", backoff, err)
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("failed to connect to database: %w", err)
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, maxConnectBackoff)
+	}
+}
+
+// connect opens the pool and pings the database
+func connect(ctx context.Context, dsn string, pool poolConfig) (*gorm.DB, error) {
+	dialector, err := openDialector(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	// The ping below honours the context, unlike the one gorm does when opening
+	db, err := gorm.Open(dialector, &gorm.Config{DisableAutomaticPing: true})
+	if err != nil {
+		return nil, err
 	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	pool.apply(sqlDB)
+
+	if err := sqlDB.PingContext(ctx); err != nil {
+		sqlDB.Close()
+		return nil, err
+	}
+	return db, nil
+}
 
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+// EnsureMigrated refuses to continue while migrations are pending, unless apply is set, in
+// which case it applies them. It then promotes the bootstrap admins.
+func EnsureMigrated(db *gorm.DB, apply bool) error {
+	pending, err := PendingMigrations(db)
 	if err != nil {
-		panic("failed to connect to database")
+		return err
 	}
 
-	EnsureMigrated(db)
+	if len(pending) > 0 && !apply {
+		names := make([]string, len(pending))
+		for i, migration := range pending {
+			names[i] = fmt.Sprintf("%d_%s", migration.Version, migration.Name)
+		}
+		return fmt.Errorf("pending migrations: %s, run `migrate up` or set AUTO_MIGRATE=true", strings.Join(names, ", "))
+	}
 
-	return db
+	if len(pending) > 0 {
+		if _, err := MigrateUp(db); err != nil {
+			return err
+		}
+	}
+
+	return promoteBootstrapAdmins(db)
 }
 
-func EnsureMigrated(db *gorm.DB) {
-	// Automigrate tables
-	if err := db.AutoMigrate(&User{}, &BurgerDay{}, &Order{}); err != nil {
-		panic(err)
+// promoteBootstrapAdmins grants the admin role to the comma separated emails in ADMIN_EMAILS,
+// so a fresh deployment has someone who can hand out roles through the API. It only does so
+// while there is no admin yet, later admins are appointed with grantRole. Like roles granted with
+// grantRole, the role follows the directory groups from the next login when the provider maps them.
+func promoteBootstrapAdmins(db *gorm.DB) error {
+	var admins int64
+	if err := db.Model(&User{}).Where("role = ?", model.RoleAdmin).Count(&admins).Error; err != nil {
+		return err
+	}
+	if admins > 0 {
+		return nil
 	}
+
+	var emails []string
+	for _, email := range strings.Split(os.Getenv("ADMIN_EMAILS"), ",") {
+		if email = strings.TrimSpace(email); email != "" {
+			emails = append(emails, email)
+		}
+	}
+	if len(emails) == 0 {
+		return nil
+	}
+
+	return db.Model(&User{}).Where("email IN ? AND role <> ?", emails, model.RoleAdmin).
+		Updates(map[string]interface{}{"role": model.RoleAdmin, "version": gorm.Expr("version + 1")}).Error
 }
 
 func UserToModel(user *User) *model.User {
 	return &model.User{
-		ID:          user.ID,
-		Name:        user.Name,
-		Email:       user.Email,
-		PhoneNumber: &user.PhoneNumber,
+		ID:             user.ID,
+		Name:           user.Name,
+		Email:          user.Email,
+		PhoneNumber:    &user.PhoneNumber,
+		Role:           user.Role,
+		ServiceAccount: user.ServiceAccount,
+		Teams:          user.Teams,
+		Deactivated:    user.DeactivatedAt != nil,
+		Version:        user.Version,
 	}
 }
 func UsersToModels(users []*User) []*model.User {
@@ -76,13 +262,26 @@ func UsersToModels(users []*User) []*model.User {
 	return models
 }
 
+// deletedAtToModel formats the deletion time, nil when the row is not deleted
+func deletedAtToModel(deletedAt gorm.DeletedAt) *string {
+	if !deletedAt.Valid {
+		return nil
+	}
+	formatted := deletedAt.Time.Format(time.RFC3339)
+	return &formatted
+}
+
 func BurgerDayToModel(burgerDay *BurgerDay) *model.BurgerDay {
 	return &model.BurgerDay{
-		ID:       burgerDay.ID,
-		Date:     burgerDay.Date,
-		AuthorId: burgerDay.AuthorId,
-		Price:    burgerDay.Price,
-		Closed:   burgerDay.Closed,
+		ID:            burgerDay.ID,
+		Date:          string(burgerDay.Date),
+		AuthorId:      burgerDay.AuthorId,
+		Price:         burgerDay.Price,
+		Closed:        burgerDay.Closed,
+		EstimatedTime: burgerDay.EstimatedTime,
+		Version:       burgerDay.Version,
+		DeletedAt:     deletedAtToModel(burgerDay.DeletedAt),
+		DeletedById:   burgerDay.DeletedById,
 	}
 }
 
@@ -122,15 +321,53 @@ func SpecialOrdersToStrings(specialOrders []model.SpecialOrders) StringArray {
 
 func OrderToModel(order *Order) *model.Order {
 	special, _ := StringsToSpecialOrders(order.SpecialRequest)
+	var paidAt *string
+	if order.PaidAt != nil {
+		formatted := order.PaidAt.Format(time.RFC3339)
+		paidAt = &formatted
+	}
 	return &model.Order{
 		ID:             order.ID,
 		BurgerDayId:    order.BurgerDayId,
 		UserId:         order.UserId,
 		SpecialRequest: special,
 		Paid:           order.Paid,
+		PaidAt:         paidAt,
+		PaidById:       order.PaidById,
+		DeletedAt:      deletedAtToModel(order.DeletedAt),
+		DeletedById:    order.DeletedById,
 	}
 }
 
+func APITokenToModel(token *APIToken) *model.APIToken {
+	scopes := make([]model.TokenScope, len(token.Scopes))
+	for i, scope := range token.Scopes {
+		scopes[i] = model.TokenScope(scope)
+	}
+	var lastUsedAt *string
+	if token.LastUsedAt != nil {
+		formatted := token.LastUsedAt.Format(time.RFC3339)
+		lastUsedAt = &formatted
+	}
+	return &model.APIToken{
+		ID:         token.ID,
+		Name:       token.Name,
+		UserId:     token.UserId,
+		Scopes:     scopes,
+		CreatedAt:  token.CreatedAt.Format(time.RFC3339),
+		ExpiresAt:  token.ExpiresAt.Format(time.RFC3339),
+		LastUsedAt: lastUsedAt,
+	}
+}
+
+func APITokensToModels(tokens []*APIToken) []*model.APIToken {
+	models := make([]*model.APIToken, len(tokens))
+	for i, token := range tokens {
+		models[i] = APITokenToModel(token)
+	}
+	return models
+}
+
 func OrdersToModels(orders []*Order) []*model.Order {
 	models := make([]*model.Order, len(orders))
 	for i, order := range orders {
@@ -143,31 +380,113 @@ func OrdersToModels(orders []*Order) []*model.Order {
 // foreign keys, and any other constraints or indexes as needed.
 
 type BurgerDay struct {
-	ID            string   `gorm:"primaryKey" json:"id"`
-	AuthorId      string   `json:"authorId"`
-	Author        *User    `gorm:"foreignKey:AuthorId" json:"author"`
-	Date          string   `gorm:"unique;type:text" json:"date"`
-	Price         float64  `gorm:"default:84.0" json:"price"`
-	Closed        bool     `gorm:"default:false" json:"closed"`
-	EstimatedTime string   `gorm:"default:12:00" json:"estimatedDeliveryTime"`
-	Orders        []*Order `gorm:"foreignKey:BurgerDayId" json:"orders"`
+	ID            string         `gorm:"primaryKey" json:"id"`
+	AuthorId      string         `json:"authorId"`
+	Author        *User          `gorm:"foreignKey:AuthorId" json:"author"`
+	Date          Date           `gorm:"uniqueIndex:idx_burger_days_date,where:deleted_at IS NULL" json:"date"` // the day in the office, unique among days that are not deleted
+	Price         float64        `gorm:"default:84.0" json:"price"`
+	Closed        bool           `gorm:"default:false" json:"closed"`
+	EstimatedTime time.Time      `gorm:"not null" json:"estimatedDeliveryTime"`
+	Version       int            `gorm:"not null;default:1" json:"version"` // bumped by every update, so concurrent edits are detected
+	Orders        []*Order       `gorm:"foreignKey:BurgerDayId" json:"orders"`
+	DeletedAt     gorm.DeletedAt `gorm:"index" json:"deletedAt"` // kept for restoring until purged, queries leave deleted days out
+	DeletedById   *string        `json:"deletedById"`
+	DeletedBy     *User          `gorm:"foreignKey:DeletedById" json:"deletedBy"`
 }
 
 type User struct {
-	ID          string       `gorm:"primaryKey" json:"id"`
-	Name        string       `json:"name"`
-	Email       string       `json:"email"`
-	PhoneNumber string       `json:"phoneNumber"`
-	BurgerDays  []*BurgerDay `gorm:"foreignKey:AuthorId" json:"burgerDays"`
-	Orders      []*Order     `gorm:"foreignKey:UserId" json:"orders"`
+	ID             string       `gorm:"primaryKey" json:"id"`
+	Name           string       `json:"name"`
+	Email          string       `json:"email"`
+	PhoneNumber    string       `json:"phoneNumber"`
+	Role           model.Role   `gorm:"type:text;default:MEMBER" json:"role"`
+	ServiceAccount bool         `gorm:"default:false" json:"serviceAccount"` // bots that only authenticate with API tokens
+	Teams          StringArray  `gorm:"default:'{}'" json:"teams"`           // office teams, synced from directory groups
+	ExternalId     *string      `gorm:"uniqueIndex" json:"externalId"`       // id in the provisioning directory, set over SCIM
+	DeactivatedAt  *time.Time   `json:"deactivatedAt"`                       // leavers can no longer log in, their orders are kept
+	Version        int          `gorm:"not null;default:1" json:"version"`   // bumped by every update, see SaveUser
+	PendingLink    bool         `gorm:"not null;default:false" json:"-"`     // provisioned by an admin or SCIM, linked by email on first login
+	BurgerDays     []*BurgerDay `gorm:"foreignKey:AuthorId" json:"burgerDays"`
+	Orders         []*Order     `gorm:"foreignKey:UserId" json:"orders"`
 }
 
 type Order struct {
-	ID             string      `gorm:"primaryKey" json:"id"`
-	BurgerDayId    string      `json:"burgerDayId"`
-	BurgerDay      *BurgerDay  `gorm:"foreignKey:BurgerDayId" json:"BurgerDay"`
-	UserId         string      `json:"userId"`
-	User           *User       `gorm:"foreignKey:UserId" json:"user"`
-	Paid           bool        `gorm:"default:false" json:"paid"`
-	SpecialRequest StringArray `gorm:"type:text[]" json:"specialRequest"`
+	ID             string         `gorm:"primaryKey" json:"id"`
+	BurgerDayId    string         `gorm:"index" json:"burgerDayId"`
+	BurgerDay      *BurgerDay     `gorm:"foreignKey:BurgerDayId" json:"BurgerDay"`
+	UserId         string         `json:"userId"`
+	User           *User          `gorm:"foreignKey:UserId" json:"user"`
+	Paid           bool           `gorm:"default:false" json:"paid"`
+	PaidAt         *time.Time     `json:"paidAt"`
+	PaidById       *string        `json:"paidById"`
+	PaidBy         *User          `gorm:"foreignKey:PaidById" json:"paidBy"`
+	SpecialRequest StringArray    `json:"specialRequest"`
+	DeletedAt      gorm.DeletedAt `gorm:"index" json:"deletedAt"` // orders deleted along with their burger day share its deletion time
+	DeletedById    *string        `json:"deletedById"`
+	DeletedBy      *User          `gorm:"foreignKey:DeletedById" json:"deletedBy"`
+}
+
+// UserIdentity links an account at an identity provider to a user. The subject is the
+// provider's stable id of the account (the Azure AD oid, the OIDC sub), which unlike the email
+// never changes. A user may have several, e.g. after accounts were merged.
+type UserIdentity struct {
+	Provider  string    `gorm:"primaryKey" json:"provider"`
+	Subject   string    `gorm:"primaryKey" json:"subject"`
+	UserId    string    `gorm:"index" json:"userId"`
+	User      *User     `gorm:"foreignKey:UserId" json:"user"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Session is a login of a user on one device. Access tokens carry the session id, and the
+// session holds the hash of the current refresh token, which is replaced on every refresh.
+// Cookie sessions hold the hash of the session cookie instead.
+type Session struct {
+	ID                       string     `gorm:"primaryKey" json:"id"`
+	UserId                   string     `gorm:"index" json:"userId"`
+	User                     *User      `gorm:"foreignKey:UserId" json:"user"`
+	RefreshTokenHash         string     `gorm:"uniqueIndex" json:"-"`
+	PreviousRefreshTokenHash string     `gorm:"index" json:"-"`
+	Cookie                   bool       `gorm:"default:false" json:"cookie"` // authenticated by a session cookie, cannot be refreshed
+	ImpersonatorId           *string    `json:"impersonatorId"`              // the admin acting as the user, cannot be refreshed
+	CreatedAt                time.Time  `json:"createdAt"`
+	ExpiresAt                time.Time  `json:"expiresAt"`
+	RevokedAt                *time.Time `json:"revokedAt"`
+}
+
+// RevokedToken is an access token id (jti) that must be rejected until the token expires
+type RevokedToken struct {
+	ID        string    `gorm:"primaryKey" json:"id"`
+	ExpiresAt time.Time `gorm:"index" json:"expiresAt"`
+}
+
+// SigningKey is a private key access tokens are signed with, shared by all replicas.
+// The id is published as the kid of the key in the JWKS.
+type SigningKey struct {
+	ID         string    `gorm:"primaryKey" json:"id"`
+	Algorithm  string    `json:"algorithm"`
+	PrivateKey string    `json:"-"` // PKCS #8 PEM
+	CreatedAt  time.Time `gorm:"index" json:"createdAt"`
+}
+
+// APIToken is a named, long-lived credential for scripts and bots. Only a hash of the
+// token is stored, the token itself is shown once when it is created.
+type APIToken struct {
+	ID         string      `gorm:"primaryKey" json:"id"`
+	Name       string      `json:"name"`
+	UserId     string      `gorm:"index" json:"userId"`
+	User       *User       `gorm:"foreignKey:UserId" json:"user"`
+	TokenHash  string      `gorm:"uniqueIndex" json:"-"`
+	Scopes     StringArray `json:"scopes"`
+	CreatedAt  time.Time   `json:"createdAt"`
+	ExpiresAt  time.Time   `json:"expiresAt"`
+	LastUsedAt *time.Time  `json:"lastUsedAt"`
+	RevokedAt  *time.Time  `json:"revokedAt"`
+}
+
+// LoginCode is a single-use code handed to the frontend after login, exchanged for tokens
+// so they never appear in a URL. The id is the hash of the code.
+type LoginCode struct {
+	ID        string    `gorm:"primaryKey" json:"-"`
+	UserId    string    `json:"userId"`
+	ExpiresAt time.Time `json:"expiresAt"`
 }
@@ -0,0 +1,468 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"graphql-go/graph/model"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// NewGormRepositories returns repositories backed by the database
+func NewGormRepositories(db *gorm.DB) Repositories {
+	return Repositories{
+		Users:      &gormUserRepo{db: db},
+		BurgerDays: &gormBurgerDayRepo{db: db},
+		Orders:     &gormOrderRepo{db: db},
+		APITokens:  &gormAPITokenRepo{db: db},
+		Sessions:   &gormSessionRepo{db: db},
+	}
+}
+
+// notFound translates gorm's not found error to ErrNotFound
+func notFound(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrNotFound
+	}
+	return err
+}
+
+// saveVersioned updates all columns of the row if its version is still the one it was read
+// with, and bumps the version. Unlike Save, it never inserts a row that is gone.
+func saveVersioned[T any](db *gorm.DB, row *T, id string, version *int) error {
+	read := *version
+	*version = read + 1
+	result := db.Model(row).Where("version = ?", read).Select("*").Omit(clause.Associations).Updates(row)
+	if result.Error == nil && result.RowsAffected == 1 {
+		return nil
+	}
+
+	*version = read
+	if result.Error != nil {
+		return result.Error
+	}
+	var count int64
+	if err := db.Model(new(T)).Where("id = ?", id).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return ErrNotFound
+	}
+	return ErrConflict
+}
+
+// SaveUser saves the user unless it was saved by someone else since it was read, which returns
+// ErrConflict. Anything updating users bumps their version, so it is never overwritten unseen.
+func SaveUser(db *gorm.DB, user *User) error {
+	return saveVersioned(db, user, user.ID, &user.Version)
+}
+
+type gormUserRepo struct {
+	db *gorm.DB
+}
+
+func (r *gormUserRepo) Get(ctx context.Context, id string) (*User, error) {
+	user := &User{}
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(user).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return user, nil
+}
+
+func (r *gormUserRepo) ListActive(ctx context.Context) ([]*User, error) {
+	var users []*User
+	if err := r.db.WithContext(ctx).Where("deactivated_at IS NULL").Find(&users).Error; err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+func (r *gormUserRepo) Create(ctx context.Context, user *User) error {
+	return r.db.WithContext(ctx).Create(user).Error
+}
+
+func (r *gormUserRepo) Save(ctx context.Context, user *User) error {
+	return SaveUser(r.db.WithContext(ctx), user)
+}
+
+func (r *gormUserRepo) SaveRole(ctx context.Context, user *User) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if user.Role != model.RoleAdmin {
+			// Locking the admins makes a concurrent demotion wait, and then find one admin less
+			var admins []string
+			err := tx.Model(&User{}).Clauses(clause.Locking{Strength: "UPDATE"}).
+				Where("role = ? AND deactivated_at IS NULL", model.RoleAdmin).
+				Pluck("id", &admins).Error
+			if err != nil {
+				return err
+			}
+			if len(admins) == 1 && admins[0] == user.ID {
+				return ErrLastAdmin
+			}
+		}
+		return SaveUser(tx, user)
+	})
+}
+
+func (r *gormUserRepo) Merge(ctx context.Context, source *User, target *User) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		reassign := []struct {
+			model  interface{}
+			column string
+		}{
+			{&BurgerDay{}, "author_id"},
+			{&BurgerDay{}, "deleted_by_id"},
+			{&Order{}, "user_id"},
+			{&Order{}, "paid_by_id"},
+			{&Order{}, "deleted_by_id"},
+			{&UserIdentity{}, "user_id"},
+			{&APIToken{}, "user_id"},
+		}
+		// Deleted burger days and orders move as well, they may still be restored
+		for _, r := range reassign {
+			updates := map[string]interface{}{r.column: target.ID}
+			// Burger days are edited with their version, which has to change along
+			if _, ok := r.model.(*BurgerDay); ok {
+				updates["version"] = gorm.Expr("version + 1")
+			}
+			if err := tx.Unscoped().Model(r.model).Where(r.column+" = ?", source.ID).Updates(updates).Error; err != nil {
+				return err
+			}
+		}
+
+		for _, model := range []interface{}{&Session{}, &LoginCode{}} {
+			if err := tx.Where("user_id = ?", source.ID).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := SaveUser(tx, target); err != nil {
+			return err
+		}
+		// What changed on the source since it was read would be lost with it
+		result := tx.Where("version = ?", source.Version).Delete(source)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrConflict
+		}
+		return nil
+	})
+}
+
+type gormBurgerDayRepo struct {
+	db *gorm.DB
+}
+
+func (r *gormBurgerDayRepo) Get(ctx context.Context, id string) (*BurgerDay, error) {
+	burgerDay := &BurgerDay{}
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(burgerDay).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return burgerDay, nil
+}
+
+func (r *gormBurgerDayRepo) GetByDate(ctx context.Context, date string) (*BurgerDay, error) {
+	burgerDay := &BurgerDay{}
+	if err := r.db.WithContext(ctx).Where("date = ?", date).First(burgerDay).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return burgerDay, nil
+}
+
+func (r *gormBurgerDayRepo) List(ctx context.Context) ([]*BurgerDay, error) {
+	var burgerDays []*BurgerDay
+	if err := r.db.WithContext(ctx).Find(&burgerDays).Error; err != nil {
+		return nil, err
+	}
+	return burgerDays, nil
+}
+
+func (r *gormBurgerDayRepo) Count(ctx context.Context) (int, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&BurgerDay{}).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+func (r *gormBurgerDayRepo) Create(ctx context.Context, burgerDay *BurgerDay) error {
+	return r.db.WithContext(ctx).Create(burgerDay).Error
+}
+
+func (r *gormBurgerDayRepo) Save(ctx context.Context, burgerDay *BurgerDay) error {
+	return saveVersioned(r.db.WithContext(ctx), burgerDay, burgerDay.ID, &burgerDay.Version)
+}
+
+func (r *gormBurgerDayRepo) Delete(ctx context.Context, id string, deletedBy string) error {
+	deleted := map[string]interface{}{"deleted_at": time.Now(), "deleted_by_id": deletedBy}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&BurgerDay{}).Where("id = ?", id).Updates(deleted)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrNotFound
+		}
+		// The orders get the same deleted_at, which is how restoring tells them apart from
+		// orders that were deleted on their own
+		return tx.Model(&Order{}).Where("burger_day_id = ?", id).Updates(deleted).Error
+	})
+}
+
+func (r *gormBurgerDayRepo) Restore(ctx context.Context, id string) (*BurgerDay, error) {
+	burgerDay := &BurgerDay{}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).First(burgerDay).Error
+		if err != nil {
+			return notFound(err)
+		}
+
+		var taken int64
+		if err := tx.Model(&BurgerDay{}).Where("date = ?", burgerDay.Date).Count(&taken).Error; err != nil {
+			return err
+		}
+		if taken > 0 {
+			return ErrDateTaken
+		}
+
+		restored := map[string]interface{}{"deleted_at": nil, "deleted_by_id": nil}
+		err = tx.Unscoped().Model(&Order{}).
+			Where("burger_day_id = ? AND deleted_at = ?", id, burgerDay.DeletedAt).
+			Updates(restored).Error
+		if err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Model(burgerDay).Updates(restored).Error; err != nil {
+			return err
+		}
+		burgerDay.DeletedAt = gorm.DeletedAt{}
+		burgerDay.DeletedById = nil
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return burgerDay, nil
+}
+
+func (r *gormBurgerDayRepo) ListDeleted(ctx context.Context) ([]*BurgerDay, error) {
+	var burgerDays []*BurgerDay
+	err := r.db.WithContext(ctx).Unscoped().Where("deleted_at IS NOT NULL").Order("deleted_at desc").Find(&burgerDays).Error
+	if err != nil {
+		return nil, err
+	}
+	return burgerDays, nil
+}
+
+func (r *gormBurgerDayRepo) PurgeDeleted(ctx context.Context, before time.Time) (int, error) {
+	var purged int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Orders of the days go first, whether they were deleted or not
+		days := tx.Unscoped().Model(&BurgerDay{}).Select("id").Where("deleted_at < ?", before)
+		if err := tx.Unscoped().Where("burger_day_id IN (?)", days).Delete(&Order{}).Error; err != nil {
+			return err
+		}
+		result := tx.Unscoped().Where("deleted_at < ?", before).Delete(&BurgerDay{})
+		purged = result.RowsAffected
+		return result.Error
+	})
+	return int(purged), err
+}
+
+type gormOrderRepo struct {
+	db *gorm.DB
+}
+
+func (r *gormOrderRepo) Get(ctx context.Context, id string) (*Order, error) {
+	order := &Order{}
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(order).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return order, nil
+}
+
+func (r *gormOrderRepo) List(ctx context.Context) ([]*Order, error) {
+	var orders []*Order
+	if err := r.db.WithContext(ctx).Find(&orders).Error; err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+func (r *gormOrderRepo) ListByBurgerDay(ctx context.Context, burgerDayID string) ([]*Order, error) {
+	var orders []*Order
+	if err := r.db.WithContext(ctx).Where("burger_day_id = ?", burgerDayID).Find(&orders).Error; err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+func (r *gormOrderRepo) CountByBurgerDay(ctx context.Context, burgerDayID string) (int, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&Order{}).Where("burger_day_id = ?", burgerDayID).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+func (r *gormOrderRepo) Count(ctx context.Context) (int, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&Order{}).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+func (r *gormOrderRepo) StatsByUser(ctx context.Context) ([]*UserOrderStats, error) {
+	// Plain SQL that runs on both Postgres and SQLite, columns are qualified since both
+	// tables have an id
+	var stats []*UserOrderStats
+	err := r.db.WithContext(ctx).Table("orders").
+		Select("users.id AS user_id, users.name AS name, users.email AS email, " +
+			"COUNT(DISTINCT orders.id) AS orders, COUNT(DISTINCT orders.burger_day_id) AS burger_days").
+		Joins("JOIN users ON users.id = orders.user_id").
+		Where("orders.deleted_at IS NULL"). // Table skips the soft delete scope of the model
+		Group("users.id, users.name, users.email").
+		Order("users.id").
+		Find(&stats).Error
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+func (r *gormOrderRepo) Create(ctx context.Context, order *Order) error {
+	return r.db.WithContext(ctx).Create(order).Error
+}
+
+func (r *gormOrderRepo) CreateForBurgerDay(ctx context.Context, order *Order, check func(*BurgerDay) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Updates of the day, like closing it, wait until the order is in. SQLite has no row
+		// locks, but only lets one transaction write at a time.
+		burgerDay := &BurgerDay{}
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", order.BurgerDayId).First(burgerDay).Error
+		if err != nil {
+			return notFound(err)
+		}
+
+		if err := check(burgerDay); err != nil {
+			return err
+		}
+		return tx.Create(order).Error
+	})
+}
+
+func (r *gormOrderRepo) Save(ctx context.Context, order *Order) error {
+	// Unlike Save, this never inserts an order that was deleted or purged since it was read
+	result := r.db.WithContext(ctx).Model(order).Where("deleted_at IS NULL").
+		Select("*").Omit(clause.Associations).Updates(order)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *gormOrderRepo) Delete(ctx context.Context, id string, deletedBy string) error {
+	result := r.db.WithContext(ctx).Model(&Order{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"deleted_at": time.Now(), "deleted_by_id": deletedBy})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *gormOrderRepo) Restore(ctx context.Context, id string) (*Order, error) {
+	order := &Order{}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).First(order).Error
+		if err != nil {
+			return notFound(err)
+		}
+
+		var live int64
+		if err := tx.Model(&BurgerDay{}).Where("id = ?", order.BurgerDayId).Count(&live).Error; err != nil {
+			return err
+		}
+		if live == 0 {
+			return ErrBurgerDayDeleted
+		}
+
+		restored := map[string]interface{}{"deleted_at": nil, "deleted_by_id": nil}
+		if err := tx.Unscoped().Model(order).Updates(restored).Error; err != nil {
+			return err
+		}
+		order.DeletedAt = gorm.DeletedAt{}
+		order.DeletedById = nil
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+func (r *gormOrderRepo) PurgeDeleted(ctx context.Context, before time.Time) (int, error) {
+	result := r.db.WithContext(ctx).Unscoped().Where("deleted_at < ?", before).Delete(&Order{})
+	return int(result.RowsAffected), result.Error
+}
+
+type gormAPITokenRepo struct {
+	db *gorm.DB
+}
+
+func (r *gormAPITokenRepo) Get(ctx context.Context, id string) (*APIToken, error) {
+	apiToken := &APIToken{}
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(apiToken).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return apiToken, nil
+}
+
+func (r *gormAPITokenRepo) ListActive(ctx context.Context, userID string) ([]*APIToken, error) {
+	var apiTokens []*APIToken
+	err := r.db.WithContext(ctx).Where("user_id = ? AND revoked_at IS NULL", userID).Order("created_at").Find(&apiTokens).Error
+	if err != nil {
+		return nil, err
+	}
+	return apiTokens, nil
+}
+
+func (r *gormAPITokenRepo) Create(ctx context.Context, apiToken *APIToken) error {
+	return r.db.WithContext(ctx).Create(apiToken).Error
+}
+
+func (r *gormAPITokenRepo) Revoke(ctx context.Context, id string) error {
+	result := r.db.WithContext(ctx).Model(&APIToken{}).Where("id = ?", id).Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+type gormSessionRepo struct {
+	db *gorm.DB
+}
+
+func (r *gormSessionRepo) Create(ctx context.Context, session *Session) error {
+	return r.db.WithContext(ctx).Create(session).Error
+}
+
+func (r *gormSessionRepo) RevokeByUser(ctx context.Context, userID string) (int, error) {
+	result := r.db.WithContext(ctx).Model(&Session{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now())
+	return int(result.RowsAffected), result.Error
+}
@@ -0,0 +1,258 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"graphql-go/graph/model"
+	"testing"
+	"time"
+)
+
+// newTestRepositories returns repositories over a migrated in-memory SQLite database
+func newTestRepositories(t *testing.T) Repositories {
+	t.Helper()
+
+	db := newTestDB(t, "sqlite::memory:")
+	if _, err := MigrateUp(db); err != nil {
+		t.Fatal(err)
+	}
+	return NewGormRepositories(db)
+}
+
+func TestSaveRole(t *testing.T) {
+	repos := newTestRepositories(t)
+	ctx := context.Background()
+
+	for _, user := range []*User{
+		{ID: "admin", Email: "admin@example.com", Role: model.RoleAdmin},
+		{ID: "other", Email: "other@example.com", Role: model.RoleAdmin},
+	} {
+		if err := repos.Users.Create(ctx, user); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	other, _ := repos.Users.Get(ctx, "other")
+	other.Role = model.RoleHost
+	if err := repos.Users.SaveRole(ctx, other); err != nil {
+		t.Fatalf("demoting one of two admins: %v", err)
+	}
+
+	admin, _ := repos.Users.Get(ctx, "admin")
+	admin.Role = model.RoleMember
+	if err := repos.Users.SaveRole(ctx, admin); !errors.Is(err, ErrLastAdmin) {
+		t.Errorf("demoting the last admin: got %v, want ErrLastAdmin", err)
+	}
+	if stored, _ := repos.Users.Get(ctx, "admin"); stored.Role != model.RoleAdmin {
+		t.Errorf("got role %s, want the last admin kept", stored.Role)
+	}
+
+	// Promoting and saving the admin are not demotions
+	other.Role = model.RoleAdmin
+	if err := repos.Users.SaveRole(ctx, other); err != nil {
+		t.Fatal(err)
+	}
+	admin.Role = model.RoleAdmin
+	if err := repos.Users.SaveRole(ctx, admin); err != nil {
+		t.Fatal(err)
+	}
+	stale := *admin
+	stale.Version--
+	stale.Role = model.RoleMember
+	if err := repos.Users.SaveRole(ctx, &stale); !errors.Is(err, ErrConflict) {
+		t.Errorf("saving a stale copy: got %v, want ErrConflict", err)
+	}
+}
+
+var errClosed = errors.New("burger day is closed")
+
+func rejectClosed(burgerDay *BurgerDay) error {
+	if burgerDay.Closed {
+		return errClosed
+	}
+	return nil
+}
+
+// Closing a day while an order holds its lock waits for the order, orders after it see the day
+// closed
+func TestCreateForBurgerDay(t *testing.T) {
+	for name, repos := range map[string]Repositories{
+		"gorm":   newTestRepositories(t),
+		"memory": NewMemoryRepositories(),
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			host := &User{ID: "host", Email: "host@example.com", Role: model.RoleHost}
+			if err := repos.Users.Create(ctx, host); err != nil {
+				t.Fatal(err)
+			}
+			if err := repos.BurgerDays.Create(ctx, &BurgerDay{ID: "day", AuthorId: host.ID, Date: "2024-03-21"}); err != nil {
+				t.Fatal(err)
+			}
+
+			locked := make(chan struct{})
+			release := make(chan struct{})
+			ordered := make(chan error)
+			go func() {
+				order := &Order{ID: "first", BurgerDayId: "day", UserId: host.ID}
+				ordered <- repos.Orders.CreateForBurgerDay(ctx, order, func(burgerDay *BurgerDay) error {
+					close(locked)
+					<-release
+					return rejectClosed(burgerDay)
+				})
+			}()
+			<-locked
+
+			closed := make(chan error)
+			go func() {
+				burgerDay, err := repos.BurgerDays.Get(ctx, "day")
+				if err == nil {
+					burgerDay.Closed = true
+					err = repos.BurgerDays.Save(ctx, burgerDay)
+				}
+				closed <- err
+			}()
+			select {
+			case err := <-closed:
+				t.Fatalf("closed the day while an order held it: %v", err)
+			case <-time.After(50 * time.Millisecond):
+			}
+
+			close(release)
+			if err := <-ordered; err != nil {
+				t.Fatalf("the order that held the day: %v", err)
+			}
+			if err := <-closed; err != nil {
+				t.Fatal(err)
+			}
+
+			// The orders racing after the close all find the day closed
+			errs := make(chan error)
+			for i := 0; i < 5; i++ {
+				go func(i int) {
+					order := &Order{ID: fmt.Sprintf("late-%d", i), BurgerDayId: "day", UserId: host.ID}
+					errs <- repos.Orders.CreateForBurgerDay(ctx, order, rejectClosed)
+				}(i)
+			}
+			for i := 0; i < 5; i++ {
+				if err := <-errs; !errors.Is(err, errClosed) {
+					t.Errorf("ordering on the closed day: got %v, want it rejected", err)
+				}
+			}
+			if count, _ := repos.Orders.CountByBurgerDay(ctx, "day"); count != 1 {
+				t.Errorf("got %d orders, want only the one placed before closing", count)
+			}
+
+			order := &Order{ID: "unknown", BurgerDayId: "missing", UserId: host.ID}
+			if err := repos.Orders.CreateForBurgerDay(ctx, order, rejectClosed); !errors.Is(err, ErrNotFound) {
+				t.Errorf("ordering on an unknown day: got %v, want ErrNotFound", err)
+			}
+		})
+	}
+}
+
+func TestRestoreAndPurge(t *testing.T) {
+	for name, repos := range map[string]Repositories{
+		"gorm":   newTestRepositories(t),
+		"memory": NewMemoryRepositories(),
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			host := &User{ID: "host", Email: "host@example.com", Role: model.RoleHost}
+			if err := repos.Users.Create(ctx, host); err != nil {
+				t.Fatal(err)
+			}
+			if err := repos.BurgerDays.Create(ctx, &BurgerDay{ID: "day", AuthorId: host.ID, Date: "2024-03-21"}); err != nil {
+				t.Fatal(err)
+			}
+			for _, id := range []string{"kept", "alone"} {
+				if err := repos.Orders.Create(ctx, &Order{ID: id, BurgerDayId: "day", UserId: host.ID}); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			// An order deleted on its own stays deleted when its day is restored
+			if err := repos.Orders.Delete(ctx, "alone", host.ID); err != nil {
+				t.Fatal(err)
+			}
+			if err := repos.BurgerDays.Delete(ctx, "day", host.ID); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := repos.Orders.Get(ctx, "kept"); !errors.Is(err, ErrNotFound) {
+				t.Errorf("getting an order of a deleted day: got %v, want ErrNotFound", err)
+			}
+			if deleted, _ := repos.BurgerDays.ListDeleted(ctx); len(deleted) != 1 || deleted[0].DeletedById == nil || *deleted[0].DeletedById != host.ID {
+				t.Errorf("got deleted days %+v, want the day deleted by the host", deleted)
+			}
+			if _, err := repos.Orders.Restore(ctx, "kept"); !errors.Is(err, ErrBurgerDayDeleted) {
+				t.Errorf("restoring an order of a deleted day: got %v, want ErrBurgerDayDeleted", err)
+			}
+
+			// Another day planned on the date keeps it
+			if err := repos.BurgerDays.Create(ctx, &BurgerDay{ID: "replacement", AuthorId: host.ID, Date: "2024-03-21"}); err != nil {
+				t.Fatal(err)
+			}
+			if err := repos.Orders.Create(ctx, &Order{ID: "replaced", BurgerDayId: "replacement", UserId: host.ID}); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := repos.BurgerDays.Restore(ctx, "day"); !errors.Is(err, ErrDateTaken) {
+				t.Errorf("restoring onto a taken date: got %v, want ErrDateTaken", err)
+			}
+			if err := repos.BurgerDays.Delete(ctx, "replacement", host.ID); err != nil {
+				t.Fatal(err)
+			}
+
+			burgerDay, err := repos.BurgerDays.Restore(ctx, "day")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if burgerDay.DeletedAt.Valid || burgerDay.DeletedById != nil {
+				t.Errorf("got restored day %+v, want it no longer deleted", burgerDay)
+			}
+			if _, err := repos.BurgerDays.Restore(ctx, "day"); !errors.Is(err, ErrNotFound) {
+				t.Errorf("restoring a live day: got %v, want ErrNotFound", err)
+			}
+			if _, err := repos.Orders.Get(ctx, "kept"); err != nil {
+				t.Errorf("getting an order restored with its day: %v", err)
+			}
+			if _, err := repos.Orders.Get(ctx, "alone"); !errors.Is(err, ErrNotFound) {
+				t.Errorf("getting an order deleted on its own: got %v, want ErrNotFound", err)
+			}
+			if _, err := repos.Orders.Restore(ctx, "alone"); err != nil {
+				t.Errorf("restoring an order deleted on its own: %v", err)
+			}
+
+			// Nothing is purged before the retention passes
+			if err := repos.Orders.Delete(ctx, "alone", host.ID); err != nil {
+				t.Fatal(err)
+			}
+			if days, _ := repos.BurgerDays.PurgeDeleted(ctx, time.Now().Add(-time.Hour)); days != 0 {
+				t.Errorf("purged %d days deleted within the retention", days)
+			}
+			if orders, _ := repos.Orders.PurgeDeleted(ctx, time.Now().Add(-time.Hour)); orders != 0 {
+				t.Errorf("purged %d orders deleted within the retention", orders)
+			}
+
+			// Purging a day takes its orders along, purged rows cannot be restored
+			later := time.Now().Add(time.Second)
+			if days, err := repos.BurgerDays.PurgeDeleted(ctx, later); err != nil || days != 1 {
+				t.Errorf("purged %d days: %v, want the replacement", days, err)
+			}
+			if orders, err := repos.Orders.PurgeDeleted(ctx, later); err != nil || orders != 1 {
+				t.Errorf("purged %d orders: %v, want the one deleted on its own", orders, err)
+			}
+			if _, err := repos.BurgerDays.Restore(ctx, "replacement"); !errors.Is(err, ErrNotFound) {
+				t.Errorf("restoring a purged day: got %v, want ErrNotFound", err)
+			}
+			for _, id := range []string{"replaced", "alone"} {
+				if _, err := repos.Orders.Restore(ctx, id); !errors.Is(err, ErrNotFound) {
+					t.Errorf("restoring purged order %s: got %v, want ErrNotFound", id, err)
+				}
+			}
+			if _, err := repos.Orders.Get(ctx, "kept"); err != nil {
+				t.Errorf("getting a live order after purging: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,713 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"graphql-go/graph/model"
+	"sort"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// NewMemoryRepositories returns repositories that keep everything in memory, for running
+// resolvers in-process without a database. They apply the same column defaults and unique
+// constraints as the schema, and hand out copies so callers cannot change stored rows.
+func NewMemoryRepositories() Repositories {
+	users := &memoryUserRepo{users: map[string]User{}}
+	burgerDays := &memoryBurgerDayRepo{burgerDays: map[string]BurgerDay{}}
+	orders := &memoryOrderRepo{orders: map[string]Order{}, burgerDays: burgerDays, users: users}
+	apiTokens := &memoryAPITokenRepo{apiTokens: map[string]APIToken{}}
+	sessions := &memorySessionRepo{sessions: map[string]Session{}}
+	burgerDays.orders = orders
+	users.burgerDays, users.orders, users.apiTokens, users.sessions = burgerDays, orders, apiTokens, sessions
+	return Repositories{
+		Users:      users,
+		BurgerDays: burgerDays,
+		Orders:     orders,
+		APITokens:  apiTokens,
+		Sessions:   sessions,
+	}
+}
+
+// copyUser copies the stored columns of the user, leaving out associations
+func copyUser(user *User) User {
+	c := *user
+	c.Teams = append(StringArray{}, user.Teams...)
+	c.BurgerDays = nil
+	c.Orders = nil
+	return c
+}
+
+func copyBurgerDay(burgerDay *BurgerDay) BurgerDay {
+	c := *burgerDay
+	c.Author = nil
+	c.Orders = nil
+	c.DeletedBy = nil
+	return c
+}
+
+func copyOrder(order *Order) Order {
+	c := *order
+	c.SpecialRequest = append(StringArray(nil), order.SpecialRequest...)
+	c.BurgerDay = nil
+	c.User = nil
+	c.PaidBy = nil
+	c.DeletedBy = nil
+	return c
+}
+
+func copyAPIToken(apiToken *APIToken) APIToken {
+	c := *apiToken
+	c.Scopes = append(StringArray(nil), apiToken.Scopes...)
+	c.User = nil
+	return c
+}
+
+// memoryUserRepo shares the rows that belong to users, to hand them over when merging. Its lock
+// is taken before that of any other repository.
+type memoryUserRepo struct {
+	mu         sync.RWMutex
+	users      map[string]User
+	burgerDays *memoryBurgerDayRepo
+	orders     *memoryOrderRepo
+	apiTokens  *memoryAPITokenRepo
+	sessions   *memorySessionRepo
+}
+
+func (r *memoryUserRepo) Get(ctx context.Context, id string) (*User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	user, ok := r.users[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	c := copyUser(&user)
+	return &c, nil
+}
+
+func (r *memoryUserRepo) ListActive(ctx context.Context) ([]*User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	users := []*User{}
+	for _, user := range r.users {
+		if user.DeactivatedAt == nil {
+			c := copyUser(&user)
+			users = append(users, &c)
+		}
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+	return users, nil
+}
+
+func (r *memoryUserRepo) Create(ctx context.Context, user *User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.users[user.ID]; ok {
+		return fmt.Errorf("duplicate user id: %s", user.ID)
+	}
+	if user.Role == "" {
+		user.Role = model.RoleMember
+	}
+	if user.Teams == nil {
+		user.Teams = StringArray{}
+	}
+	if user.Version == 0 {
+		user.Version = 1
+	}
+	return r.save(user)
+}
+
+func (r *memoryUserRepo) Save(ctx context.Context, user *User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.saveVersion(user)
+}
+
+func (r *memoryUserRepo) SaveRole(ctx context.Context, user *User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if stored, ok := r.users[user.ID]; ok && user.Role != model.RoleAdmin && stored.Role == model.RoleAdmin && stored.DeactivatedAt == nil {
+		others := 0
+		for id, other := range r.users {
+			if id != user.ID && other.Role == model.RoleAdmin && other.DeactivatedAt == nil {
+				others++
+			}
+		}
+		if others == 0 {
+			return ErrLastAdmin
+		}
+	}
+	return r.saveVersion(user)
+}
+
+// saveVersion saves the user if it is the stored version, bumping it. The lock must be held.
+func (r *memoryUserRepo) saveVersion(user *User) error {
+	stored, ok := r.users[user.ID]
+	if !ok {
+		return ErrNotFound
+	}
+	if stored.Version != user.Version {
+		return ErrConflict
+	}
+
+	user.Version++
+	if err := r.save(user); err != nil {
+		user.Version--
+		return err
+	}
+	return nil
+}
+
+func (r *memoryUserRepo) Merge(ctx context.Context, source *User, target *User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	storedSource, ok := r.users[source.ID]
+	if !ok {
+		return ErrNotFound
+	}
+	storedTarget, ok := r.users[target.ID]
+	if !ok {
+		return ErrNotFound
+	}
+	if storedSource.Version != source.Version || storedTarget.Version != target.Version {
+		return ErrConflict
+	}
+
+	delete(r.users, source.ID)
+	target.Version++
+	if err := r.save(target); err != nil {
+		target.Version--
+		r.users[source.ID] = storedSource
+		return err
+	}
+
+	// Deleted burger days and orders move as well, they may still be restored
+	reassign := func(id **string) {
+		if *id != nil && **id == source.ID {
+			*id = &target.ID
+		}
+	}
+	r.burgerDays.mu.Lock()
+	defer r.burgerDays.mu.Unlock()
+	for id, burgerDay := range r.burgerDays.burgerDays {
+		moved := burgerDay
+		if moved.AuthorId == source.ID {
+			moved.AuthorId = target.ID
+		}
+		reassign(&moved.DeletedById)
+		// Burger days are edited with their version, which has to change along
+		if moved.AuthorId != burgerDay.AuthorId || moved.DeletedById != burgerDay.DeletedById {
+			moved.Version++
+			r.burgerDays.burgerDays[id] = moved
+		}
+	}
+
+	r.orders.mu.Lock()
+	defer r.orders.mu.Unlock()
+	for id, order := range r.orders.orders {
+		if order.UserId == source.ID {
+			order.UserId = target.ID
+		}
+		reassign(&order.PaidById)
+		reassign(&order.DeletedById)
+		r.orders.orders[id] = order
+	}
+
+	r.apiTokens.mu.Lock()
+	defer r.apiTokens.mu.Unlock()
+	for id, apiToken := range r.apiTokens.apiTokens {
+		if apiToken.UserId == source.ID {
+			apiToken.UserId = target.ID
+			r.apiTokens.apiTokens[id] = apiToken
+		}
+	}
+
+	r.sessions.mu.Lock()
+	defer r.sessions.mu.Unlock()
+	for id, session := range r.sessions.sessions {
+		if session.UserId == source.ID {
+			delete(r.sessions.sessions, id)
+		}
+	}
+	return nil
+}
+
+func (r *memoryUserRepo) save(user *User) error {
+	if user.ExternalId != nil {
+		for id, other := range r.users {
+			if id != user.ID && other.ExternalId != nil && *other.ExternalId == *user.ExternalId {
+				return fmt.Errorf("duplicate external id: %s", *user.ExternalId)
+			}
+		}
+	}
+	r.users[user.ID] = copyUser(user)
+	return nil
+}
+
+// memoryBurgerDayRepo shares the orders, to delete and restore them along with their day. The
+// burger days lock is always taken before the orders lock.
+type memoryBurgerDayRepo struct {
+	mu         sync.RWMutex
+	burgerDays map[string]BurgerDay
+	orders     *memoryOrderRepo
+}
+
+// live returns the burger day unless it is missing or deleted, the caller holds the lock
+func (r *memoryBurgerDayRepo) live(id string) (BurgerDay, bool) {
+	burgerDay, ok := r.burgerDays[id]
+	return burgerDay, ok && !burgerDay.DeletedAt.Valid
+}
+
+func (r *memoryBurgerDayRepo) Get(ctx context.Context, id string) (*BurgerDay, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	burgerDay, ok := r.live(id)
+	if !ok {
+		return nil, ErrNotFound
+	}
+	c := copyBurgerDay(&burgerDay)
+	return &c, nil
+}
+
+func (r *memoryBurgerDayRepo) GetByDate(ctx context.Context, date string) (*BurgerDay, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, burgerDay := range r.burgerDays {
+		if string(burgerDay.Date) == date && !burgerDay.DeletedAt.Valid {
+			c := copyBurgerDay(&burgerDay)
+			return &c, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (r *memoryBurgerDayRepo) List(ctx context.Context) ([]*BurgerDay, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	burgerDays := []*BurgerDay{}
+	for _, burgerDay := range r.burgerDays {
+		if !burgerDay.DeletedAt.Valid {
+			c := copyBurgerDay(&burgerDay)
+			burgerDays = append(burgerDays, &c)
+		}
+	}
+	sort.Slice(burgerDays, func(i, j int) bool { return burgerDays[i].Date < burgerDays[j].Date })
+	return burgerDays, nil
+}
+
+func (r *memoryBurgerDayRepo) Count(ctx context.Context) (int, error) {
+	burgerDays, err := r.List(ctx)
+	return len(burgerDays), err
+}
+
+func (r *memoryBurgerDayRepo) Create(ctx context.Context, burgerDay *BurgerDay) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.burgerDays[burgerDay.ID]; ok {
+		return fmt.Errorf("duplicate burger day id: %s", burgerDay.ID)
+	}
+	if burgerDay.Price == 0 {
+		burgerDay.Price = 84.0
+	}
+	if burgerDay.Version == 0 {
+		burgerDay.Version = 1
+	}
+	return r.save(burgerDay)
+}
+
+func (r *memoryBurgerDayRepo) Save(ctx context.Context, burgerDay *BurgerDay) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.live(burgerDay.ID)
+	if !ok {
+		return ErrNotFound
+	}
+	if stored.Version != burgerDay.Version {
+		return ErrConflict
+	}
+
+	burgerDay.Version++
+	if err := r.save(burgerDay); err != nil {
+		burgerDay.Version--
+		return err
+	}
+	return nil
+}
+
+func (r *memoryBurgerDayRepo) save(burgerDay *BurgerDay) error {
+	// Like the partial unique index, only days that are not deleted need distinct dates
+	if !burgerDay.DeletedAt.Valid && r.dateTaken(burgerDay.ID, burgerDay.Date) {
+		return fmt.Errorf("duplicate burger day date: %s", burgerDay.Date)
+	}
+	r.burgerDays[burgerDay.ID] = copyBurgerDay(burgerDay)
+	return nil
+}
+
+// dateTaken reports whether another day that is not deleted is on the date
+func (r *memoryBurgerDayRepo) dateTaken(id string, date Date) bool {
+	for otherID, other := range r.burgerDays {
+		if otherID != id && other.Date == date && !other.DeletedAt.Valid {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *memoryBurgerDayRepo) Delete(ctx context.Context, id string, deletedBy string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	burgerDay, ok := r.live(id)
+	if !ok {
+		return ErrNotFound
+	}
+	deletedAt := gorm.DeletedAt{Time: time.Now(), Valid: true}
+	burgerDay.DeletedAt = deletedAt
+	burgerDay.DeletedById = &deletedBy
+	r.burgerDays[id] = burgerDay
+
+	r.orders.mu.Lock()
+	defer r.orders.mu.Unlock()
+	for orderID, order := range r.orders.orders {
+		if order.BurgerDayId == id && !order.DeletedAt.Valid {
+			order.DeletedAt = deletedAt
+			order.DeletedById = &deletedBy
+			r.orders.orders[orderID] = order
+		}
+	}
+	return nil
+}
+
+func (r *memoryBurgerDayRepo) Restore(ctx context.Context, id string) (*BurgerDay, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	burgerDay, ok := r.burgerDays[id]
+	if !ok || !burgerDay.DeletedAt.Valid {
+		return nil, ErrNotFound
+	}
+	if r.dateTaken(id, burgerDay.Date) {
+		return nil, ErrDateTaken
+	}
+
+	r.orders.mu.Lock()
+	defer r.orders.mu.Unlock()
+	for orderID, order := range r.orders.orders {
+		if order.BurgerDayId == id && order.DeletedAt.Valid && order.DeletedAt.Time.Equal(burgerDay.DeletedAt.Time) {
+			order.DeletedAt = gorm.DeletedAt{}
+			order.DeletedById = nil
+			r.orders.orders[orderID] = order
+		}
+	}
+
+	burgerDay.DeletedAt = gorm.DeletedAt{}
+	burgerDay.DeletedById = nil
+	r.burgerDays[id] = burgerDay
+	c := copyBurgerDay(&burgerDay)
+	return &c, nil
+}
+
+func (r *memoryBurgerDayRepo) ListDeleted(ctx context.Context) ([]*BurgerDay, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	burgerDays := []*BurgerDay{}
+	for _, burgerDay := range r.burgerDays {
+		if burgerDay.DeletedAt.Valid {
+			c := copyBurgerDay(&burgerDay)
+			burgerDays = append(burgerDays, &c)
+		}
+	}
+	sort.Slice(burgerDays, func(i, j int) bool {
+		return burgerDays[i].DeletedAt.Time.After(burgerDays[j].DeletedAt.Time)
+	})
+	return burgerDays, nil
+}
+
+func (r *memoryBurgerDayRepo) PurgeDeleted(ctx context.Context, before time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.orders.mu.Lock()
+	defer r.orders.mu.Unlock()
+	purged := 0
+	for id, burgerDay := range r.burgerDays {
+		if burgerDay.DeletedAt.Valid && burgerDay.DeletedAt.Time.Before(before) {
+			for orderID, order := range r.orders.orders {
+				if order.BurgerDayId == id {
+					delete(r.orders.orders, orderID)
+				}
+			}
+			delete(r.burgerDays, id)
+			purged++
+		}
+	}
+	return purged, nil
+}
+
+type memoryOrderRepo struct {
+	mu         sync.RWMutex
+	orders     map[string]Order
+	burgerDays *memoryBurgerDayRepo
+	users      *memoryUserRepo
+}
+
+func (r *memoryOrderRepo) Get(ctx context.Context, id string) (*Order, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	order, ok := r.orders[id]
+	if !ok || order.DeletedAt.Valid {
+		return nil, ErrNotFound
+	}
+	c := copyOrder(&order)
+	return &c, nil
+}
+
+func (r *memoryOrderRepo) List(ctx context.Context) ([]*Order, error) {
+	return r.list(func(*Order) bool { return true }), nil
+}
+
+func (r *memoryOrderRepo) ListByBurgerDay(ctx context.Context, burgerDayID string) ([]*Order, error) {
+	return r.list(func(order *Order) bool { return order.BurgerDayId == burgerDayID }), nil
+}
+
+func (r *memoryOrderRepo) CountByBurgerDay(ctx context.Context, burgerDayID string) (int, error) {
+	return len(r.list(func(order *Order) bool { return order.BurgerDayId == burgerDayID })), nil
+}
+
+func (r *memoryOrderRepo) Count(ctx context.Context) (int, error) {
+	return len(r.list(func(*Order) bool { return true })), nil
+}
+
+func (r *memoryOrderRepo) StatsByUser(ctx context.Context) ([]*UserOrderStats, error) {
+	orders := r.list(func(*Order) bool { return true })
+
+	r.users.mu.RLock()
+	defer r.users.mu.RUnlock()
+	byUser := map[string]*UserOrderStats{}
+	burgerDays := map[string]map[string]bool{}
+	for _, order := range orders {
+		// Like the join, orders of users that are gone are left out
+		user, ok := r.users.users[order.UserId]
+		if !ok {
+			continue
+		}
+		stat, ok := byUser[user.ID]
+		if !ok {
+			stat = &UserOrderStats{UserID: user.ID, Name: user.Name, Email: user.Email}
+			byUser[user.ID] = stat
+			burgerDays[user.ID] = map[string]bool{}
+		}
+		stat.Orders++
+		burgerDays[user.ID][order.BurgerDayId] = true
+		stat.BurgerDays = len(burgerDays[user.ID])
+	}
+
+	stats := make([]*UserOrderStats, 0, len(byUser))
+	for _, stat := range byUser {
+		stats = append(stats, stat)
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].UserID < stats[j].UserID })
+	return stats, nil
+}
+
+func (r *memoryOrderRepo) list(match func(*Order) bool) []*Order {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	orders := []*Order{}
+	for _, order := range r.orders {
+		if !order.DeletedAt.Valid && match(&order) {
+			c := copyOrder(&order)
+			orders = append(orders, &c)
+		}
+	}
+	sort.Slice(orders, func(i, j int) bool { return orders[i].ID < orders[j].ID })
+	return orders
+}
+
+func (r *memoryOrderRepo) Create(ctx context.Context, order *Order) error {
+	// Like the foreign key, an order needs an existing burger day
+	if _, err := r.burgerDays.Get(ctx, order.BurgerDayId); err != nil {
+		return fmt.Errorf("burger day does not exist: %s", order.BurgerDayId)
+	}
+	return r.create(order)
+}
+
+func (r *memoryOrderRepo) create(order *Order) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.orders[order.ID]; ok {
+		return fmt.Errorf("duplicate order id: %s", order.ID)
+	}
+	r.orders[order.ID] = copyOrder(order)
+	return nil
+}
+
+func (r *memoryOrderRepo) CreateForBurgerDay(ctx context.Context, order *Order, check func(*BurgerDay) error) error {
+	// Holding the burger days lock keeps the day from changing until the order is in
+	r.burgerDays.mu.Lock()
+	defer r.burgerDays.mu.Unlock()
+
+	burgerDay, ok := r.burgerDays.live(order.BurgerDayId)
+	if !ok {
+		return ErrNotFound
+	}
+	c := copyBurgerDay(&burgerDay)
+	if err := check(&c); err != nil {
+		return err
+	}
+
+	return r.create(order)
+}
+
+func (r *memoryOrderRepo) Save(ctx context.Context, order *Order) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if stored, ok := r.orders[order.ID]; !ok || stored.DeletedAt.Valid {
+		return ErrNotFound
+	}
+	r.orders[order.ID] = copyOrder(order)
+	return nil
+}
+
+func (r *memoryOrderRepo) Delete(ctx context.Context, id string, deletedBy string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	order, ok := r.orders[id]
+	if !ok || order.DeletedAt.Valid {
+		return ErrNotFound
+	}
+	order.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+	order.DeletedById = &deletedBy
+	r.orders[id] = order
+	return nil
+}
+
+func (r *memoryOrderRepo) Restore(ctx context.Context, id string) (*Order, error) {
+	r.burgerDays.mu.RLock()
+	defer r.burgerDays.mu.RUnlock()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	order, ok := r.orders[id]
+	if !ok || !order.DeletedAt.Valid {
+		return nil, ErrNotFound
+	}
+	if _, ok := r.burgerDays.live(order.BurgerDayId); !ok {
+		return nil, ErrBurgerDayDeleted
+	}
+
+	order.DeletedAt = gorm.DeletedAt{}
+	order.DeletedById = nil
+	r.orders[id] = order
+	c := copyOrder(&order)
+	return &c, nil
+}
+
+func (r *memoryOrderRepo) PurgeDeleted(ctx context.Context, before time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	purged := 0
+	for id, order := range r.orders {
+		if order.DeletedAt.Valid && order.DeletedAt.Time.Before(before) {
+			delete(r.orders, id)
+			purged++
+		}
+	}
+	return purged, nil
+}
+
+type memoryAPITokenRepo struct {
+	mu        sync.RWMutex
+	apiTokens map[string]APIToken
+}
+
+func (r *memoryAPITokenRepo) Get(ctx context.Context, id string) (*APIToken, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	apiToken, ok := r.apiTokens[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	c := copyAPIToken(&apiToken)
+	return &c, nil
+}
+
+func (r *memoryAPITokenRepo) ListActive(ctx context.Context, userID string) ([]*APIToken, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	apiTokens := []*APIToken{}
+	for _, apiToken := range r.apiTokens {
+		if apiToken.UserId == userID && apiToken.RevokedAt == nil {
+			c := copyAPIToken(&apiToken)
+			apiTokens = append(apiTokens, &c)
+		}
+	}
+	sort.Slice(apiTokens, func(i, j int) bool { return apiTokens[i].CreatedAt.Before(apiTokens[j].CreatedAt) })
+	return apiTokens, nil
+}
+
+func (r *memoryAPITokenRepo) Create(ctx context.Context, apiToken *APIToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for id, other := range r.apiTokens {
+		if id == apiToken.ID {
+			return fmt.Errorf("duplicate API token id: %s", id)
+		}
+		if other.TokenHash == apiToken.TokenHash {
+			return errors.New("duplicate API token hash")
+		}
+	}
+	if apiToken.CreatedAt.IsZero() {
+		apiToken.CreatedAt = time.Now()
+	}
+	r.apiTokens[apiToken.ID] = copyAPIToken(apiToken)
+	return nil
+}
+
+func (r *memoryAPITokenRepo) Revoke(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	apiToken, ok := r.apiTokens[id]
+	if !ok {
+		return ErrNotFound
+	}
+	now := time.Now()
+	apiToken.RevokedAt = &now
+	r.apiTokens[id] = apiToken
+	return nil
+}
+
+type memorySessionRepo struct {
+	mu       sync.Mutex
+	sessions map[string]Session
+}
+
+func (r *memorySessionRepo) Create(ctx context.Context, session *Session) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.sessions[session.ID]; ok {
+		return fmt.Errorf("duplicate session id: %s", session.ID)
+	}
+	if session.CreatedAt.IsZero() {
+		session.CreatedAt = time.Now()
+	}
+	c := *session
+	c.User = nil
+	r.sessions[session.ID] = c
+	return nil
+}
+
+func (r *memorySessionRepo) RevokeByUser(ctx context.Context, userID string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	revoked := 0
+	for id, session := range r.sessions {
+		if session.UserId == userID && session.RevokedAt == nil {
+			session.RevokedAt = &now
+			r.sessions[id] = session
+			revoked++
+		}
+	}
+	return revoked, nil
+}
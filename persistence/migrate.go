@@ -0,0 +1,314 @@
+package persistence
+
+import (
+	"embed"
+	"fmt"
+	"graphql-go/core/office"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// migrationFiles are the schema changes, named <version>_<name>.up.sql with a matching
+// .down.sql that reverts it. Versions are applied in ascending order and never renumbered.
+// Each dialect has its own directory, and every migration is written for all of them under
+// the same version.
+//
+//go:embed migrations/*/*.sql
+var migrationFiles embed.FS
+
+// migrationLockKey identifies the advisory lock replicas take while migrating, so only one
+// applies a migration while the others wait and then find it applied
+const migrationLockKey = 7428301
+
+var migrationFileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// migrationSteps finish what the SQL of a migration cannot do on its own, by dialect and
+// version. They run after the up file in the same transaction.
+var migrationSteps = map[string]map[int]func(tx *gorm.DB) error{
+	"sqlite": {4: officeTimeOffsets},
+}
+
+// Migration is one versioned schema change
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// SchemaMigration records an applied migration
+type SchemaMigration struct {
+	Version   int `gorm:"primaryKey;autoIncrement:false"`
+	Name      string
+	AppliedAt time.Time
+}
+
+// MigrationStatus is a migration and when it was applied, nil if it is pending
+type MigrationStatus struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+// Migrations returns the embedded migrations for the dialect ("postgres" or "sqlite") in order
+func Migrations(dialect string) ([]Migration, error) {
+	dir := path.Join("migrations", dialect)
+	entries, err := fs.ReadDir(migrationFiles, dir)
+	if err != nil {
+		return nil, fmt.Errorf("no migrations for %s databases", dialect)
+	}
+
+	byVersion := map[int]*Migration{}
+	for _, entry := range entries {
+		match := migrationFileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name: %s", entry.Name())
+		}
+		version, _ := strconv.Atoi(match[1])
+
+		content, err := migrationFiles.ReadFile(path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has differently named files", version)
+		}
+		if match[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if strings.TrimSpace(migration.Up) == "" || strings.TrimSpace(migration.Down) == "" {
+			return nil, fmt.Errorf("migration %d needs both an up and a down file", migration.Version)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+func ensureMigrationsTable(db *gorm.DB) error {
+	timestamp := "timestamptz"
+	if db.Dialector.Name() == "sqlite" {
+		timestamp = "datetime"
+	}
+	return db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version bigint PRIMARY KEY,
+		name text NOT NULL,
+		applied_at ` + timestamp + ` NOT NULL
+	)`).Error
+}
+
+// migrationTransaction runs fn in a transaction that concurrent migrations wait for. On Postgres
+// the office timezone is set as office.timezone for the migrations to read. SQLite needs no
+// lock, it only lets one connection write at a time anyway. Changing a table there
+// means copying it, which its foreign keys would prevent, so they are turned off meanwhile and
+// checked before the commit, as SQLite recommends.
+func migrationTransaction(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+	if db.Dialector.Name() != "sqlite" {
+		return db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", migrationLockKey).Error; err != nil {
+				return err
+			}
+			if err := tx.Exec("SELECT set_config('office.timezone', ?, true)", office.Location().String()).Error; err != nil {
+				return err
+			}
+			return fn(tx)
+		})
+	}
+
+	// The pragma only applies to the connection and cannot change inside a transaction
+	return db.Connection(func(conn *gorm.DB) error {
+		if err := conn.Exec("PRAGMA foreign_keys = OFF").Error; err != nil {
+			return err
+		}
+		defer conn.Exec("PRAGMA foreign_keys = ON")
+
+		return conn.Transaction(func(tx *gorm.DB) error {
+			if err := fn(tx); err != nil {
+				return err
+			}
+
+			var violations []map[string]interface{}
+			if err := tx.Raw("PRAGMA foreign_key_check").Scan(&violations).Error; err != nil {
+				return err
+			}
+			if len(violations) > 0 {
+				return fmt.Errorf("%d rows would violate foreign keys", len(violations))
+			}
+			return nil
+		})
+	})
+}
+
+// MigrationStatuses lists every migration and whether it has been applied
+func MigrationStatuses(db *gorm.DB) ([]MigrationStatus, error) {
+	migrations, err := Migrations(db.Dialector.Name())
+	if err != nil {
+		return nil, err
+	}
+	if err := ensureMigrationsTable(db); err != nil {
+		return nil, err
+	}
+
+	var applied []SchemaMigration
+	if err := db.Find(&applied).Error; err != nil {
+		return nil, err
+	}
+	appliedAt := map[int]time.Time{}
+	for _, m := range applied {
+		appliedAt[m.Version] = m.AppliedAt
+	}
+
+	statuses := make([]MigrationStatus, len(migrations))
+	for i, migration := range migrations {
+		statuses[i] = MigrationStatus{Migration: migration}
+		if at, ok := appliedAt[migration.Version]; ok {
+			statuses[i].AppliedAt = &at
+		}
+	}
+	return statuses, nil
+}
+
+// PendingMigrations returns the migrations that have not been applied yet
+func PendingMigrations(db *gorm.DB) ([]Migration, error) {
+	statuses, err := MigrationStatuses(db)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []Migration
+	for _, status := range statuses {
+		if status.AppliedAt == nil {
+			pending = append(pending, status.Migration)
+		}
+	}
+	return pending, nil
+}
+
+// MigrateUp applies all pending migrations and returns the ones it applied. Each runs in its
+// own transaction, so a failing migration leaves the schema at the previous version.
+func MigrateUp(db *gorm.DB) ([]Migration, error) {
+	pending, err := PendingMigrations(db)
+	if err != nil {
+		return nil, err
+	}
+
+	var applied []Migration
+	for _, migration := range pending {
+		ran := false
+		err := migrationTransaction(db, func(tx *gorm.DB) error {
+			// Another replica may have applied it while we waited for the lock
+			var count int64
+			if err := tx.Model(&SchemaMigration{}).Where("version = ?", migration.Version).Count(&count).Error; err != nil {
+				return err
+			}
+			if count > 0 {
+				return nil
+			}
+
+			if err := tx.Exec(migration.Up).Error; err != nil {
+				return err
+			}
+			if step, ok := migrationSteps[db.Dialector.Name()][migration.Version]; ok {
+				if err := step(tx); err != nil {
+					return err
+				}
+			}
+			ran = true
+			return tx.Create(&SchemaMigration{
+				Version:   migration.Version,
+				Name:      migration.Name,
+				AppliedAt: time.Now(),
+			}).Error
+		})
+		if err != nil {
+			return applied, fmt.Errorf("migration %d_%s failed: %w", migration.Version, migration.Name, err)
+		}
+		if ran {
+			applied = append(applied, migration)
+		}
+	}
+
+	return applied, nil
+}
+
+// MigrateDown reverts the latest steps applied migrations and returns the ones it reverted
+func MigrateDown(db *gorm.DB, steps int) ([]Migration, error) {
+	statuses, err := MigrationStatuses(db)
+	if err != nil {
+		return nil, err
+	}
+
+	var latest []Migration
+	for i := len(statuses) - 1; i >= 0 && len(latest) < steps; i-- {
+		if statuses[i].AppliedAt != nil {
+			latest = append(latest, statuses[i].Migration)
+		}
+	}
+
+	var reverted []Migration
+	for _, migration := range latest {
+		ran := false
+		err := migrationTransaction(db, func(tx *gorm.DB) error {
+			res := tx.Where("version = ?", migration.Version).Delete(&SchemaMigration{})
+			if res.Error != nil || res.RowsAffected == 0 {
+				return res.Error
+			}
+
+			ran = true
+			return tx.Exec(migration.Down).Error
+		})
+		if err != nil {
+			return reverted, fmt.Errorf("reverting migration %d_%s failed: %w", migration.Version, migration.Name, err)
+		}
+		if ran {
+			reverted = append(reverted, migration)
+		}
+	}
+
+	return reverted, nil
+}
+
+// officeTimeOffsets gives the estimated times 0004 copied without an offset the one the office
+// had on that day. SQLite cannot look up timezones, so the date and time are read back and
+// written again as a time in office.Location().
+func officeTimeOffsets(tx *gorm.DB) error {
+	var rows []struct {
+		ID            string
+		EstimatedTime string
+	}
+	if err := tx.Raw("SELECT id, CAST(estimated_time AS text) AS estimated_time FROM burger_days").Scan(&rows).Error; err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		estimated, err := time.ParseInLocation("2006-01-02 15:04:05", row.EstimatedTime, office.Location())
+		if err != nil {
+			return fmt.Errorf("burger day %s: %w", row.ID, err)
+		}
+		if err := tx.Exec("UPDATE burger_days SET estimated_time = ? WHERE id = ?", estimated, row.ID).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+package persistence
+
+import (
+	"context"
+	"graphql-go/core/office"
+	"graphql-go/graph/model"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// newTestDB connects to a fresh SQLite database, without migrating it
+func newTestDB(t *testing.T, dsn string) *gorm.DB {
+	t.Helper()
+
+	t.Setenv("DB_URL", dsn)
+	db, err := ConnectGORM(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+	return db
+}
+
+func migrationNames(migrations []Migration) []string {
+	names := make([]string, len(migrations))
+	for i, migration := range migrations {
+		names[i] = migration.Name
+	}
+	return names
+}
+
+func TestMigrations(t *testing.T) {
+	for _, dialect := range []string{"postgres", "sqlite"} {
+		migrations, err := Migrations(dialect)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, migration := range migrations {
+			if migration.Version != i+1 {
+				t.Errorf("%s: migration %s has version %d, want %d", dialect, migration.Name, migration.Version, i+1)
+			}
+		}
+		if sqlite, _ := Migrations("sqlite"); len(sqlite) != len(migrations) {
+			t.Errorf("%s has %d migrations, sqlite %d", dialect, len(migrations), len(sqlite))
+		}
+	}
+
+	if _, err := Migrations("mysql"); err == nil {
+		t.Error("found migrations for mysql")
+	}
+}
+
+func TestMigrateUpDown(t *testing.T) {
+	db := newTestDB(t, "sqlite::memory:")
+	migrations, err := Migrations("sqlite")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = EnsureMigrated(db, false)
+	if err == nil || !strings.Contains(err.Error(), "pending migrations: 1_baseline, 2_orders_burger_day_fk") {
+		t.Fatalf("starting on an empty database: got %v, want the pending migrations", err)
+	}
+	if db.Migrator().HasTable("users") {
+		t.Fatal("refusing to start created the schema")
+	}
+
+	applied, err := MigrateUp(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(applied) != len(migrations) {
+		t.Fatalf("applied %v, want all %d migrations", migrationNames(applied), len(migrations))
+	}
+	if err := EnsureMigrated(db, false); err != nil {
+		t.Fatalf("starting on a migrated database: %v", err)
+	}
+	if applied, err := MigrateUp(db); err != nil || len(applied) != 0 {
+		t.Errorf("migrating again applied %v: %v", migrationNames(applied), err)
+	}
+
+	// Data written at the latest version survives a round trip through the down files
+	user := &User{ID: "host", Name: "Host", Email: "host@example.com", Role: model.RoleHost, Teams: StringArray{"Copenhagen"}}
+	if err := db.Create(user).Error; err != nil {
+		t.Fatal(err)
+	}
+	// The app writes estimated times on the office's clock, which is what the down files keep
+	estimated := time.Date(2024, 3, 21, 11, 30, 0, 0, office.Location())
+	burgerDay := &BurgerDay{ID: "day", AuthorId: user.ID, Date: "2024-03-21", EstimatedTime: estimated}
+	if err := db.Create(burgerDay).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Create(&Order{ID: "order", BurgerDayId: burgerDay.ID, UserId: user.ID, SpecialRequest: StringArray{"no onions"}}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	reverted, err := MigrateDown(db, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(migrationNames(reverted), ","); got != "pending_link,versions,burger_day_dates" {
+		t.Errorf("reverted %s, want the latest three from the newest", got)
+	}
+	err = EnsureMigrated(db, false)
+	if err == nil || !strings.Contains(err.Error(), "4_burger_day_dates, 5_versions, 6_pending_link") {
+		t.Errorf("starting after reverting: got %v, want the three pending migrations", err)
+	}
+	if err := EnsureMigrated(db, true); err != nil {
+		t.Fatalf("starting with AUTO_MIGRATE: %v", err)
+	}
+
+	var day BurgerDay
+	if err := db.First(&day, "id = ?", burgerDay.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if day.Date != burgerDay.Date || !day.EstimatedTime.Equal(estimated) || day.AuthorId != user.ID {
+		t.Errorf("got burger day %+v after down and up, want %+v", day, burgerDay)
+	}
+	var order Order
+	if err := db.First(&order, "id = ?", "order").Error; err != nil || order.BurgerDayId != burgerDay.ID {
+		t.Errorf("got order %+v after down and up: %v", order, err)
+	}
+
+	// Reverting everything leaves only the bookkeeping
+	reverted, err = MigrateDown(db, len(migrations)+1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reverted) != len(migrations) {
+		t.Errorf("reverted %v, want all %d migrations", migrationNames(reverted), len(migrations))
+	}
+	for _, table := range []string{"users", "burger_days", "orders", "sessions", "api_tokens", "user_identities"} {
+		if db.Migrator().HasTable(table) {
+			t.Errorf("table %s is left after reverting every migration", table)
+		}
+	}
+	statuses, err := MigrationStatuses(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, status := range statuses {
+		if status.AppliedAt != nil {
+			t.Errorf("%d_%s is still applied", status.Version, status.Name)
+		}
+	}
+
+	if applied, err := MigrateUp(db); err != nil || len(applied) != len(migrations) {
+		t.Errorf("migrating up again applied %v: %v", migrationNames(applied), err)
+	}
+}
+
+func TestMigrateDownNothingApplied(t *testing.T) {
+	db := newTestDB(t, "sqlite::memory:")
+
+	reverted, err := MigrateDown(db, 1)
+	if err != nil || len(reverted) != 0 {
+		t.Errorf("reverting an empty database reverted %v: %v", migrationNames(reverted), err)
+	}
+}
+
+// Replicas starting together each try to apply the migrations, every one must be applied once
+func TestMigrateUpConcurrently(t *testing.T) {
+	dsn := "sqlite:" + filepath.Join(t.TempDir(), "replicas.db")
+	replicas := []*gorm.DB{newTestDB(t, dsn), newTestDB(t, dsn), newTestDB(t, dsn)}
+
+	applied := make([][]Migration, len(replicas))
+	errs := make([]error, len(replicas))
+	var wg sync.WaitGroup
+	for i, db := range replicas {
+		wg.Add(1)
+		go func(i int, db *gorm.DB) {
+			defer wg.Done()
+			applied[i], errs[i] = MigrateUp(db)
+		}(i, db)
+	}
+	wg.Wait()
+
+	total := 0
+	for i := range replicas {
+		if errs[i] != nil {
+			t.Errorf("replica %d: %v", i, errs[i])
+		}
+		total += len(applied[i])
+	}
+	migrations, _ := Migrations("sqlite")
+	if total != len(migrations) {
+		t.Errorf("applied %d migrations across replicas, want each of the %d once", total, len(migrations))
+	}
+}
@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS user_identities;
+DROP TABLE IF EXISTS login_codes;
+DROP TABLE IF EXISTS api_tokens;
+DROP TABLE IF EXISTS signing_keys;
+DROP TABLE IF EXISTS revoked_tokens;
+DROP TABLE IF EXISTS sessions;
+DROP TABLE IF EXISTS orders;
+DROP TABLE IF EXISTS burger_days;
+DROP TABLE IF EXISTS users;
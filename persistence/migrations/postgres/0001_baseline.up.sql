@@ -0,0 +1,105 @@
+-- The schema as AutoMigrate left it. Databases created by AutoMigrate already have most of it,
+-- so every statement is a no-op when the object exists.
+
+CREATE TABLE IF NOT EXISTS users (
+	id text PRIMARY KEY,
+	name text,
+	email text,
+	phone_number text
+);
+ALTER TABLE users ADD COLUMN IF NOT EXISTS role text DEFAULT 'MEMBER';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS service_account boolean DEFAULT false;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS teams text[] DEFAULT '{}';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS external_id text;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deactivated_at timestamptz;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_external_id ON users (external_id);
+
+CREATE TABLE IF NOT EXISTS burger_days (
+	id text PRIMARY KEY,
+	author_id text,
+	date text,
+	price decimal DEFAULT 84,
+	closed boolean DEFAULT false,
+	estimated_time text DEFAULT '12:00',
+	CONSTRAINT fk_users_burger_days FOREIGN KEY (author_id) REFERENCES users (id),
+	CONSTRAINT uni_burger_days_date UNIQUE (date)
+);
+
+CREATE TABLE IF NOT EXISTS orders (
+	id text PRIMARY KEY,
+	burger_day_id text,
+	user_id text,
+	paid boolean DEFAULT false,
+	special_request text[],
+	CONSTRAINT fk_burger_days_orders FOREIGN KEY (burger_day_id) REFERENCES burger_days (id),
+	CONSTRAINT fk_users_orders FOREIGN KEY (user_id) REFERENCES users (id)
+);
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS paid_at timestamptz;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS paid_by_id text;
+DO $$
+BEGIN
+	ALTER TABLE orders ADD CONSTRAINT fk_orders_paid_by FOREIGN KEY (paid_by_id) REFERENCES users (id);
+EXCEPTION
+	WHEN duplicate_object THEN NULL;
+END $$;
+
+CREATE TABLE IF NOT EXISTS sessions (
+	id text PRIMARY KEY,
+	user_id text,
+	refresh_token_hash text,
+	previous_refresh_token_hash text,
+	created_at timestamptz,
+	expires_at timestamptz,
+	revoked_at timestamptz,
+	CONSTRAINT fk_sessions_user FOREIGN KEY (user_id) REFERENCES users (id)
+);
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS cookie boolean DEFAULT false;
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS impersonator_id text;
+CREATE INDEX IF NOT EXISTS idx_sessions_user_id ON sessions (user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_sessions_refresh_token_hash ON sessions (refresh_token_hash);
+CREATE INDEX IF NOT EXISTS idx_sessions_previous_refresh_token_hash ON sessions (previous_refresh_token_hash);
+
+CREATE TABLE IF NOT EXISTS revoked_tokens (
+	id text PRIMARY KEY,
+	expires_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_revoked_tokens_expires_at ON revoked_tokens (expires_at);
+
+CREATE TABLE IF NOT EXISTS signing_keys (
+	id text PRIMARY KEY,
+	algorithm text,
+	private_key text,
+	created_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_signing_keys_created_at ON signing_keys (created_at);
+
+CREATE TABLE IF NOT EXISTS api_tokens (
+	id text PRIMARY KEY,
+	name text,
+	user_id text,
+	token_hash text,
+	scopes text[],
+	created_at timestamptz,
+	expires_at timestamptz,
+	last_used_at timestamptz,
+	revoked_at timestamptz,
+	CONSTRAINT fk_api_tokens_user FOREIGN KEY (user_id) REFERENCES users (id)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_api_tokens_token_hash ON api_tokens (token_hash);
+CREATE INDEX IF NOT EXISTS idx_api_tokens_user_id ON api_tokens (user_id);
+
+CREATE TABLE IF NOT EXISTS login_codes (
+	id text PRIMARY KEY,
+	user_id text,
+	expires_at timestamptz
+);
+
+CREATE TABLE IF NOT EXISTS user_identities (
+	provider text,
+	subject text,
+	user_id text,
+	created_at timestamptz,
+	PRIMARY KEY (provider, subject),
+	CONSTRAINT fk_user_identities_user FOREIGN KEY (user_id) REFERENCES users (id)
+);
+CREATE INDEX IF NOT EXISTS idx_user_identities_user_id ON user_identities (user_id);
@@ -0,0 +1,3 @@
+-- This migration is irreversible, only its index is dropped. The orders it deleted are gone,
+-- and the constraint stays since the baseline creates it too.
+DROP INDEX IF EXISTS idx_orders_burger_day_id;
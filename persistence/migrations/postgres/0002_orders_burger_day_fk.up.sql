@@ -0,0 +1,12 @@
+-- Orders used to be created for burger day ids that do not exist. They belong to no day and
+-- cannot be shown anywhere, so they are removed before the constraint is added.
+DELETE FROM orders WHERE burger_day_id NOT IN (SELECT id FROM burger_days);
+
+-- AutoMigrate created the constraint along with the association, but not on every database
+DO $$
+BEGIN
+	ALTER TABLE orders ADD CONSTRAINT fk_burger_days_orders FOREIGN KEY (burger_day_id) REFERENCES burger_days (id);
+EXCEPTION
+	WHEN duplicate_object THEN NULL;
+END $$;
+CREATE INDEX IF NOT EXISTS idx_orders_burger_day_id ON orders (burger_day_id);
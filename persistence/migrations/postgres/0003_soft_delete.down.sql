@@ -0,0 +1,11 @@
+-- Without the columns deleted rows would come back, so they are purged
+DELETE FROM orders WHERE deleted_at IS NOT NULL OR burger_day_id IN (SELECT id FROM burger_days WHERE deleted_at IS NOT NULL);
+DELETE FROM burger_days WHERE deleted_at IS NOT NULL;
+
+DROP INDEX idx_burger_days_date;
+ALTER TABLE burger_days ADD CONSTRAINT uni_burger_days_date UNIQUE (date);
+
+ALTER TABLE orders DROP COLUMN deleted_by_id;
+ALTER TABLE orders DROP COLUMN deleted_at;
+ALTER TABLE burger_days DROP COLUMN deleted_by_id;
+ALTER TABLE burger_days DROP COLUMN deleted_at;
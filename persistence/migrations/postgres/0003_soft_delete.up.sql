@@ -0,0 +1,17 @@
+-- Deleted burger days and orders are kept until they are purged, so admins can restore them.
+-- Orders deleted along with their burger day share its deleted_at.
+ALTER TABLE burger_days ADD COLUMN deleted_at timestamptz;
+ALTER TABLE burger_days ADD COLUMN deleted_by_id text;
+ALTER TABLE burger_days ADD CONSTRAINT fk_burger_days_deleted_by FOREIGN KEY (deleted_by_id) REFERENCES users (id);
+CREATE INDEX idx_burger_days_deleted_at ON burger_days (deleted_at);
+
+ALTER TABLE orders ADD COLUMN deleted_at timestamptz;
+ALTER TABLE orders ADD COLUMN deleted_by_id text;
+ALTER TABLE orders ADD CONSTRAINT fk_orders_deleted_by FOREIGN KEY (deleted_by_id) REFERENCES users (id);
+CREATE INDEX idx_orders_deleted_at ON orders (deleted_at);
+
+-- A deleted day keeps its date, which must not keep a new day from being started on it.
+-- Databases created by older versions of AutoMigrate named the constraint differently.
+ALTER TABLE burger_days DROP CONSTRAINT IF EXISTS uni_burger_days_date;
+ALTER TABLE burger_days DROP CONSTRAINT IF EXISTS burger_days_date_key;
+CREATE UNIQUE INDEX idx_burger_days_date ON burger_days (date) WHERE deleted_at IS NULL;
@@ -0,0 +1,6 @@
+ALTER TABLE burger_days ALTER COLUMN estimated_time DROP NOT NULL;
+ALTER TABLE burger_days ALTER COLUMN estimated_time TYPE text
+	USING to_char(estimated_time AT TIME ZONE current_setting('office.timezone'), 'HH24:MI');
+ALTER TABLE burger_days ALTER COLUMN estimated_time SET DEFAULT '12:00';
+
+ALTER TABLE burger_days ALTER COLUMN date TYPE text USING to_char("date", 'YYYY-MM-DD');
@@ -0,0 +1,13 @@
+-- Burger days are dated in the office timezone and the estimated delivery becomes a point in
+-- time. Estimated times were entered as HH:MM on the office's clock, anything else falls back
+-- to noon. The office timezone is the OFFICE_TIMEZONE the migration runs with.
+ALTER TABLE burger_days ALTER COLUMN date TYPE date USING "date"::date;
+
+ALTER TABLE burger_days ALTER COLUMN estimated_time DROP DEFAULT;
+ALTER TABLE burger_days ALTER COLUMN estimated_time TYPE timestamptz USING (
+	"date" + CASE
+		WHEN estimated_time ~ '^([01]?[0-9]|2[0-3]):[0-5][0-9]$' THEN estimated_time::time
+		ELSE time '12:00'
+	END
+) AT TIME ZONE current_setting('office.timezone');
+ALTER TABLE burger_days ALTER COLUMN estimated_time SET NOT NULL;
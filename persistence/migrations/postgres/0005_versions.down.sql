@@ -0,0 +1,2 @@
+ALTER TABLE burger_days DROP COLUMN version;
+ALTER TABLE users DROP COLUMN version;
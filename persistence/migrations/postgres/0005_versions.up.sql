@@ -0,0 +1,4 @@
+-- Every update bumps the version, so clients editing a stale copy are told instead of
+-- overwriting the changes of others
+ALTER TABLE burger_days ADD COLUMN version integer NOT NULL DEFAULT 1;
+ALTER TABLE users ADD COLUMN version integer NOT NULL DEFAULT 1;
@@ -0,0 +1,3 @@
+-- Only users provisioned by an admin or SCIM are linked by email on their first login. Users
+-- that exist already are never linked, admins merge them instead.
+ALTER TABLE users ADD COLUMN pending_link boolean NOT NULL DEFAULT false;
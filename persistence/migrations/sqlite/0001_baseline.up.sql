@@ -0,0 +1,100 @@
+-- The baseline schema for SQLite, matching the Postgres baseline. Arrays are stored as text in
+-- the Postgres array format and timestamps as datetime, which the driver parses back.
+
+CREATE TABLE IF NOT EXISTS users (
+	id text PRIMARY KEY,
+	name text,
+	email text,
+	phone_number text,
+	role text DEFAULT 'MEMBER',
+	service_account boolean DEFAULT false,
+	teams text DEFAULT '{}',
+	external_id text,
+	deactivated_at datetime
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_external_id ON users (external_id);
+
+CREATE TABLE IF NOT EXISTS burger_days (
+	id text PRIMARY KEY,
+	author_id text,
+	date text,
+	price real DEFAULT 84,
+	closed boolean DEFAULT false,
+	estimated_time text DEFAULT '12:00',
+	CONSTRAINT fk_users_burger_days FOREIGN KEY (author_id) REFERENCES users (id),
+	CONSTRAINT uni_burger_days_date UNIQUE (date)
+);
+
+CREATE TABLE IF NOT EXISTS orders (
+	id text PRIMARY KEY,
+	burger_day_id text,
+	user_id text,
+	paid boolean DEFAULT false,
+	paid_at datetime,
+	paid_by_id text,
+	special_request text,
+	CONSTRAINT fk_burger_days_orders FOREIGN KEY (burger_day_id) REFERENCES burger_days (id),
+	CONSTRAINT fk_users_orders FOREIGN KEY (user_id) REFERENCES users (id),
+	CONSTRAINT fk_orders_paid_by FOREIGN KEY (paid_by_id) REFERENCES users (id)
+);
+
+CREATE TABLE IF NOT EXISTS sessions (
+	id text PRIMARY KEY,
+	user_id text,
+	refresh_token_hash text,
+	previous_refresh_token_hash text,
+	cookie boolean DEFAULT false,
+	impersonator_id text,
+	created_at datetime,
+	expires_at datetime,
+	revoked_at datetime,
+	CONSTRAINT fk_sessions_user FOREIGN KEY (user_id) REFERENCES users (id)
+);
+CREATE INDEX IF NOT EXISTS idx_sessions_user_id ON sessions (user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_sessions_refresh_token_hash ON sessions (refresh_token_hash);
+CREATE INDEX IF NOT EXISTS idx_sessions_previous_refresh_token_hash ON sessions (previous_refresh_token_hash);
+
+CREATE TABLE IF NOT EXISTS revoked_tokens (
+	id text PRIMARY KEY,
+	expires_at datetime
+);
+CREATE INDEX IF NOT EXISTS idx_revoked_tokens_expires_at ON revoked_tokens (expires_at);
+
+CREATE TABLE IF NOT EXISTS signing_keys (
+	id text PRIMARY KEY,
+	algorithm text,
+	private_key text,
+	created_at datetime
+);
+CREATE INDEX IF NOT EXISTS idx_signing_keys_created_at ON signing_keys (created_at);
+
+CREATE TABLE IF NOT EXISTS api_tokens (
+	id text PRIMARY KEY,
+	name text,
+	user_id text,
+	token_hash text,
+	scopes text,
+	created_at datetime,
+	expires_at datetime,
+	last_used_at datetime,
+	revoked_at datetime,
+	CONSTRAINT fk_api_tokens_user FOREIGN KEY (user_id) REFERENCES users (id)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_api_tokens_token_hash ON api_tokens (token_hash);
+CREATE INDEX IF NOT EXISTS idx_api_tokens_user_id ON api_tokens (user_id);
+
+CREATE TABLE IF NOT EXISTS login_codes (
+	id text PRIMARY KEY,
+	user_id text,
+	expires_at datetime
+);
+
+CREATE TABLE IF NOT EXISTS user_identities (
+	provider text,
+	subject text,
+	user_id text,
+	created_at datetime,
+	PRIMARY KEY (provider, subject),
+	CONSTRAINT fk_user_identities_user FOREIGN KEY (user_id) REFERENCES users (id)
+);
+CREATE INDEX IF NOT EXISTS idx_user_identities_user_id ON user_identities (user_id);
@@ -0,0 +1,2 @@
+-- This migration is irreversible, only its index is dropped. The orders it deleted are gone.
+DROP INDEX IF EXISTS idx_orders_burger_day_id;
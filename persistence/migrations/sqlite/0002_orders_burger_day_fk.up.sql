@@ -0,0 +1,4 @@
+-- The baseline already has the constraint, but it is only enforced on connections that turn
+-- foreign keys on
+DELETE FROM orders WHERE burger_day_id NOT IN (SELECT id FROM burger_days);
+CREATE INDEX IF NOT EXISTS idx_orders_burger_day_id ON orders (burger_day_id);
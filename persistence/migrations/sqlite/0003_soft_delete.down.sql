@@ -0,0 +1,37 @@
+-- Without the columns deleted rows would come back, so they are purged
+DELETE FROM orders WHERE deleted_at IS NOT NULL OR burger_day_id IN (SELECT id FROM burger_days WHERE deleted_at IS NOT NULL);
+DELETE FROM burger_days WHERE deleted_at IS NOT NULL;
+
+CREATE TABLE burger_days_old (
+	id text PRIMARY KEY,
+	author_id text,
+	date text,
+	price real DEFAULT 84,
+	closed boolean DEFAULT false,
+	estimated_time text DEFAULT '12:00',
+	CONSTRAINT fk_users_burger_days FOREIGN KEY (author_id) REFERENCES users (id),
+	CONSTRAINT uni_burger_days_date UNIQUE (date)
+);
+INSERT INTO burger_days_old (id, author_id, date, price, closed, estimated_time)
+	SELECT id, author_id, date, price, closed, estimated_time FROM burger_days;
+DROP TABLE burger_days;
+ALTER TABLE burger_days_old RENAME TO burger_days;
+
+-- A column with a foreign key cannot be dropped, so orders are copied as well
+CREATE TABLE orders_old (
+	id text PRIMARY KEY,
+	burger_day_id text,
+	user_id text,
+	paid boolean DEFAULT false,
+	paid_at datetime,
+	paid_by_id text,
+	special_request text,
+	CONSTRAINT fk_burger_days_orders FOREIGN KEY (burger_day_id) REFERENCES burger_days (id),
+	CONSTRAINT fk_users_orders FOREIGN KEY (user_id) REFERENCES users (id),
+	CONSTRAINT fk_orders_paid_by FOREIGN KEY (paid_by_id) REFERENCES users (id)
+);
+INSERT INTO orders_old (id, burger_day_id, user_id, paid, paid_at, paid_by_id, special_request)
+	SELECT id, burger_day_id, user_id, paid, paid_at, paid_by_id, special_request FROM orders;
+DROP TABLE orders;
+ALTER TABLE orders_old RENAME TO orders;
+CREATE INDEX idx_orders_burger_day_id ON orders (burger_day_id);
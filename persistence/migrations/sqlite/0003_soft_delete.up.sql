@@ -0,0 +1,27 @@
+-- Deleted burger days and orders are kept until they are purged, so admins can restore them.
+-- Orders deleted along with their burger day share its deleted_at.
+--
+-- A deleted day keeps its date, which must not keep a new day from being started on it. SQLite
+-- cannot drop the unique constraint, so the table is copied without it.
+CREATE TABLE burger_days_new (
+	id text PRIMARY KEY,
+	author_id text,
+	date text,
+	price real DEFAULT 84,
+	closed boolean DEFAULT false,
+	estimated_time text DEFAULT '12:00',
+	deleted_at datetime,
+	deleted_by_id text,
+	CONSTRAINT fk_users_burger_days FOREIGN KEY (author_id) REFERENCES users (id),
+	CONSTRAINT fk_burger_days_deleted_by FOREIGN KEY (deleted_by_id) REFERENCES users (id)
+);
+INSERT INTO burger_days_new (id, author_id, date, price, closed, estimated_time)
+	SELECT id, author_id, date, price, closed, estimated_time FROM burger_days;
+DROP TABLE burger_days;
+ALTER TABLE burger_days_new RENAME TO burger_days;
+CREATE UNIQUE INDEX idx_burger_days_date ON burger_days (date) WHERE deleted_at IS NULL;
+CREATE INDEX idx_burger_days_deleted_at ON burger_days (deleted_at);
+
+ALTER TABLE orders ADD COLUMN deleted_at datetime;
+ALTER TABLE orders ADD COLUMN deleted_by_id text CONSTRAINT fk_orders_deleted_by REFERENCES users (id);
+CREATE INDEX idx_orders_deleted_at ON orders (deleted_at);
@@ -0,0 +1,20 @@
+-- Estimated times are written on the office's clock, which the text keeps before its offset
+CREATE TABLE burger_days_old (
+	id text PRIMARY KEY,
+	author_id text,
+	date text,
+	price real DEFAULT 84,
+	closed boolean DEFAULT false,
+	estimated_time text DEFAULT '12:00',
+	deleted_at datetime,
+	deleted_by_id text,
+	CONSTRAINT fk_users_burger_days FOREIGN KEY (author_id) REFERENCES users (id),
+	CONSTRAINT fk_burger_days_deleted_by FOREIGN KEY (deleted_by_id) REFERENCES users (id)
+);
+INSERT INTO burger_days_old (id, author_id, date, price, closed, estimated_time, deleted_at, deleted_by_id)
+	SELECT id, author_id, date, price, closed, substr(estimated_time, 12, 5), deleted_at, deleted_by_id
+	FROM burger_days;
+DROP TABLE burger_days;
+ALTER TABLE burger_days_old RENAME TO burger_days;
+CREATE UNIQUE INDEX idx_burger_days_date ON burger_days (date) WHERE deleted_at IS NULL;
+CREATE INDEX idx_burger_days_deleted_at ON burger_days (deleted_at);
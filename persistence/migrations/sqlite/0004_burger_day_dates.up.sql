@@ -0,0 +1,30 @@
+-- The estimated delivery becomes a point in time. Estimated times were entered as HH:MM on the
+-- office's clock, anything else falls back to noon. SQLite knows no timezones, so they are
+-- copied without an offset here and officeTimeOffsets adds the one of OFFICE_TIMEZONE. Dates
+-- stay text, SQLite has no date type.
+CREATE TABLE burger_days_new (
+	id text PRIMARY KEY,
+	author_id text,
+	date text,
+	price real DEFAULT 84,
+	closed boolean DEFAULT false,
+	estimated_time datetime NOT NULL,
+	deleted_at datetime,
+	deleted_by_id text,
+	CONSTRAINT fk_users_burger_days FOREIGN KEY (author_id) REFERENCES users (id),
+	CONSTRAINT fk_burger_days_deleted_by FOREIGN KEY (deleted_by_id) REFERENCES users (id)
+);
+INSERT INTO burger_days_new (id, author_id, date, price, closed, estimated_time, deleted_at, deleted_by_id)
+	SELECT id, author_id, date, price, closed,
+		date || ' ' ||
+		CASE
+			WHEN estimated_time GLOB '[0-9]:[0-5][0-9]' THEN '0' || estimated_time
+			WHEN estimated_time GLOB '[01][0-9]:[0-5][0-9]' OR estimated_time GLOB '2[0-3]:[0-5][0-9]' THEN estimated_time
+			ELSE '12:00'
+		END || ':00',
+		deleted_at, deleted_by_id
+	FROM burger_days;
+DROP TABLE burger_days;
+ALTER TABLE burger_days_new RENAME TO burger_days;
+CREATE UNIQUE INDEX idx_burger_days_date ON burger_days (date) WHERE deleted_at IS NULL;
+CREATE INDEX idx_burger_days_deleted_at ON burger_days (deleted_at);
@@ -0,0 +1,63 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"time"
+)
+
+const (
+	defaultDeletedRetention = 30 * 24 * time.Hour
+	purgeInterval           = time.Hour
+)
+
+// StartPurge removes deleted burger days and orders for good once they have been deleted for
+// longer than DELETED_RETENTION, e.g. 720h. Until then admins can restore them. It purges right
+// away and then every hour until the context is done.
+func StartPurge(ctx context.Context, repos Repositories) error {
+	retention := defaultDeletedRetention
+	if value := os.Getenv("DELETED_RETENTION"); value != "" {
+		// Refuse a typo rather than falling back, purged rows cannot be brought back
+		d, err := time.ParseDuration(value)
+		if err != nil || d <= 0 {
+			return fmt.Errorf("invalid DELETED_RETENTION: %s", value)
+		}
+		retention = d
+	}
+
+	purgeDeleted(ctx, repos, retention)
+	go func() {
+		ticker := time.NewTicker(purgeInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				purgeDeleted(ctx, repos, retention)
+			}
+		}
+	}()
+	return nil
+}
+
+func purgeDeleted(ctx context.Context, repos Repositories, retention time.Duration) {
+	before := time.Now().Add(-retention)
+
+	burgerDays, err := repos.BurgerDays.PurgeDeleted(ctx, before)
+	if err != nil {
+		log.Printf("purging deleted burger days failed: %v", err)
+		return
+	}
+	orders, err := repos.Orders.PurgeDeleted(ctx, before)
+	if err != nil {
+		log.Printf("purging deleted orders failed: %v", err)
+		return
+	}
+	if burgerDays > 0 || orders > 0 {
+		log.Printf("purged %d deleted burger days and %d deleted orders", burgerDays, orders)
+	}
+}
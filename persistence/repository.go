@@ -0,0 +1,118 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrNotFound is returned by repositories when the requested row does not exist
+var ErrNotFound = errors.New("record not found")
+
+// ErrBurgerDayDeleted is returned when restoring an order whose burger day is deleted
+var ErrBurgerDayDeleted = errors.New("burger day is deleted")
+
+// ErrConflict is returned when saving a row that was changed since it was read
+var ErrConflict = errors.New("record was changed in the meantime")
+
+// ErrLastAdmin is returned when a role change would leave nobody who can grant roles
+var ErrLastAdmin = errors.New("the last admin cannot be demoted, grant someone else the ADMIN role first")
+
+// ErrDateTaken is returned when restoring a burger day on a date another day was started on
+var ErrDateTaken = errors.New("another burger day exists on the date")
+
+// UserRepo stores users
+type UserRepo interface {
+	Get(ctx context.Context, id string) (*User, error)
+	// ListActive returns the users that have not been deactivated
+	ListActive(ctx context.Context) ([]*User, error)
+	Create(ctx context.Context, user *User) error
+	// Save returns ErrConflict when the user was saved by someone else since it was read
+	Save(ctx context.Context, user *User) error
+	// SaveRole saves the user like Save, unless that demotes the last active admin, in which case
+	// it returns ErrLastAdmin. Concurrent demotions wait for each other, so they cannot each leave
+	// the other as the last admin.
+	SaveRole(ctx context.Context, user *User) error
+	// Merge hands everything of the source user over to the target, saves the target and
+	// deletes the source. Sessions of the source are ended instead. It returns ErrConflict when
+	// either was saved by someone else since it was read.
+	Merge(ctx context.Context, source *User, target *User) error
+}
+
+// BurgerDayRepo stores burger days. Deleted days are left out of everything but ListDeleted.
+type BurgerDayRepo interface {
+	Get(ctx context.Context, id string) (*BurgerDay, error)
+	// GetByDate returns the burger day on the date, formatted as YYYY-MM-DD
+	GetByDate(ctx context.Context, date string) (*BurgerDay, error)
+	List(ctx context.Context) ([]*BurgerDay, error)
+	Count(ctx context.Context) (int, error)
+	Create(ctx context.Context, burgerDay *BurgerDay) error
+	// Save returns ErrConflict when the burger day was saved by someone else since it was read
+	Save(ctx context.Context, burgerDay *BurgerDay) error
+	// Delete soft deletes the burger day and its orders on behalf of the user
+	Delete(ctx context.Context, id string, deletedBy string) error
+	// Restore undeletes the burger day along with the orders that were deleted with it
+	Restore(ctx context.Context, id string) (*BurgerDay, error)
+	// ListDeleted returns the deleted burger days, most recently deleted first
+	ListDeleted(ctx context.Context) ([]*BurgerDay, error)
+	// PurgeDeleted removes the burger days deleted before the time for good, returning how many
+	PurgeDeleted(ctx context.Context, before time.Time) (int, error)
+}
+
+// OrderRepo stores orders. Deleted orders are left out of everything.
+type OrderRepo interface {
+	Get(ctx context.Context, id string) (*Order, error)
+	List(ctx context.Context) ([]*Order, error)
+	ListByBurgerDay(ctx context.Context, burgerDayID string) ([]*Order, error)
+	CountByBurgerDay(ctx context.Context, burgerDayID string) (int, error)
+	Count(ctx context.Context) (int, error)
+	// StatsByUser counts the orders of every user who placed any
+	StatsByUser(ctx context.Context) ([]*UserOrderStats, error)
+	Create(ctx context.Context, order *Order) error
+	// CreateForBurgerDay creates the order if check accepts its burger day, holding a lock on
+	// the day so it cannot change in between. It returns ErrNotFound for an unknown day.
+	CreateForBurgerDay(ctx context.Context, order *Order, check func(*BurgerDay) error) error
+	// Save returns ErrNotFound when the order was deleted since it was read
+	Save(ctx context.Context, order *Order) error
+	// Delete soft deletes the order on behalf of the user
+	Delete(ctx context.Context, id string, deletedBy string) error
+	// Restore undeletes the order. It returns ErrBurgerDayDeleted while its burger day is deleted.
+	Restore(ctx context.Context, id string) (*Order, error)
+	// PurgeDeleted removes the orders deleted before the time for good, returning how many
+	PurgeDeleted(ctx context.Context, before time.Time) (int, error)
+}
+
+// UserOrderStats counts the orders of a user and the burger days they ordered on
+type UserOrderStats struct {
+	UserID     string
+	Name       string
+	Email      string
+	Orders     int
+	BurgerDays int
+}
+
+// APITokenRepo stores API tokens
+type APITokenRepo interface {
+	Get(ctx context.Context, id string) (*APIToken, error)
+	// ListActive returns the tokens of the user that have not been revoked, oldest first
+	ListActive(ctx context.Context, userID string) ([]*APIToken, error)
+	Create(ctx context.Context, apiToken *APIToken) error
+	// Revoke returns ErrNotFound when the token was deleted since it was read
+	Revoke(ctx context.Context, id string) error
+}
+
+// SessionRepo stores login sessions
+type SessionRepo interface {
+	Create(ctx context.Context, session *Session) error
+	// RevokeByUser revokes every active session of the user and returns how many were revoked
+	RevokeByUser(ctx context.Context, userID string) (int, error)
+}
+
+// Repositories bundles the repositories the resolvers work with
+type Repositories struct {
+	Users      UserRepo
+	BurgerDays BurgerDayRepo
+	Orders     OrderRepo
+	APITokens  APITokenRepo
+	Sessions   SessionRepo
+}
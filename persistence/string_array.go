@@ -0,0 +1,186 @@
+package persistence
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// StringArray is a text[] column, written in the Postgres array format. A nil array is stored
+// as NULL and an empty one as {}, so both scan back as they were.
+type StringArray []string
+
+// arraySpecial are the characters that make an element need quotes
+const arraySpecial = "{}\",\\ \t\n\r\v\f"
+
+// Value encodes the array as a Postgres array literal. Elements are quoted when they are
+// empty, read NULL or contain special characters or whitespace.
+func (a StringArray) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, element := range a {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		if element != "" && !strings.EqualFold(element, "NULL") && !strings.ContainsAny(element, arraySpecial) {
+			b.WriteString(element)
+			continue
+		}
+
+		b.WriteByte('"')
+		for j := 0; j < len(element); j++ {
+			if element[j] == '"' || element[j] == '\\' {
+				b.WriteByte('\\')
+			}
+			b.WriteByte(element[j])
+		}
+		b.WriteByte('"')
+	}
+	b.WriteByte('}')
+
+	return b.String(), nil
+}
+
+// Scan decodes a one-dimensional Postgres array literal, as text or bytes depending on the
+// driver. NULL scans as a nil array, NULL elements are rejected since a string cannot hold them.
+func (a *StringArray) Scan(src interface{}) error {
+	var literal string
+	switch src := src.(type) {
+	case nil:
+		*a = nil
+		return nil
+	case string:
+		literal = src
+	case []byte:
+		literal = string(src)
+	default:
+		return fmt.Errorf("cannot scan %T into StringArray", src)
+	}
+
+	elements, err := parseArray(literal)
+	if err != nil {
+		return fmt.Errorf("invalid array %q: %w", literal, err)
+	}
+	*a = elements
+	return nil
+}
+
+// GormDataType tells gorm the column type, which it cannot infer from Value since nil
+// arrays are NULL
+func (StringArray) GormDataType() string {
+	return "text"
+}
+
+// GormDBDataType stores arrays as native text arrays on Postgres, and as text in the same
+// format elsewhere
+func (StringArray) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	if db.Dialector.Name() == "postgres" {
+		return "text[]"
+	}
+	return "text"
+}
+
+func isArraySpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\v' || c == '\f'
+}
+
+// parseArray parses the literal the way Postgres reads array input: elements are separated by
+// commas, may be double quoted, and backslash escapes the next character in either form.
+// Whitespace around unquoted elements is not part of them.
+func parseArray(literal string) ([]string, error) {
+	s := strings.TrimFunc(literal, func(r rune) bool { return r < 0x80 && isArraySpace(byte(r)) })
+	if len(s) < 2 || s[0] != '{' || s[len(s)-1] != '}' {
+		return nil, errors.New("must be enclosed in braces")
+	}
+	s = s[1 : len(s)-1]
+
+	elements := []string{}
+	if strings.TrimFunc(s, func(r rune) bool { return r < 0x80 && isArraySpace(byte(r)) }) == "" {
+		return elements, nil
+	}
+
+	i := 0
+	for {
+		for i < len(s) && isArraySpace(s[i]) {
+			i++
+		}
+		if i < len(s) && s[i] == '{' {
+			return nil, errors.New("multidimensional arrays are not supported")
+		}
+
+		var element strings.Builder
+		if i < len(s) && s[i] == '"' {
+			i++
+			closed := false
+			for i < len(s) && !closed {
+				switch s[i] {
+				case '\\':
+					i++
+					if i == len(s) {
+						return nil, errors.New("unexpected end after backslash")
+					}
+					element.WriteByte(s[i])
+				case '"':
+					closed = true
+				default:
+					element.WriteByte(s[i])
+				}
+				i++
+			}
+			if !closed {
+				return nil, errors.New("unterminated quoted element")
+			}
+			for i < len(s) && isArraySpace(s[i]) {
+				i++
+			}
+		} else {
+			// Trailing whitespace is dropped, unless it was escaped
+			kept, escaped := 0, false
+			for i < len(s) && s[i] != ',' {
+				switch {
+				case s[i] == '\\':
+					i++
+					if i == len(s) {
+						return nil, errors.New("unexpected end after backslash")
+					}
+					element.WriteByte(s[i])
+					kept, escaped = element.Len(), true
+				case s[i] == '"' || s[i] == '{' || s[i] == '}':
+					return nil, fmt.Errorf("unexpected %q in unquoted element", s[i])
+				default:
+					element.WriteByte(s[i])
+					if !isArraySpace(s[i]) {
+						kept = element.Len()
+					}
+				}
+				i++
+			}
+			value := element.String()[:kept]
+			if value == "" {
+				return nil, errors.New("empty unquoted element")
+			}
+			if !escaped && strings.EqualFold(value, "NULL") {
+				return nil, errors.New("NULL elements are not supported")
+			}
+			element.Reset()
+			element.WriteString(value)
+		}
+
+		elements = append(elements, element.String())
+		if i == len(s) {
+			return elements, nil
+		}
+		if s[i] != ',' {
+			return nil, fmt.Errorf("expected a comma, got %q", s[i])
+		}
+		i++
+	}
+}
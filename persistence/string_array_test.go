@@ -0,0 +1,112 @@
+package persistence
+
+import (
+	"reflect"
+	"testing"
+)
+
+// roundTrip writes the array with Value and reads it back with Scan, as text and as bytes
+// since drivers hand out either
+func roundTrip(t *testing.T, array StringArray) {
+	t.Helper()
+
+	value, err := array.Value()
+	if err != nil {
+		t.Fatalf("Value(%q) failed: %v", array, err)
+	}
+
+	sources := []interface{}{value}
+	if literal, ok := value.(string); ok {
+		sources = append(sources, []byte(literal))
+	}
+	for _, src := range sources {
+		var scanned StringArray
+		if err := scanned.Scan(src); err != nil {
+			t.Fatalf("Scan(%q) of %q failed: %v", src, array, err)
+		}
+		if !reflect.DeepEqual(scanned, array) {
+			t.Fatalf("%q came back as %q from %q", array, scanned, src)
+		}
+	}
+}
+
+func TestStringArrayRoundTrip(t *testing.T) {
+	arrays := []StringArray{
+		nil,
+		{},
+		{""},
+		{"", ""},
+		{"plain"},
+		{"Copenhagen", "Aarhus"},
+		{"with space", " leading", "trailing "},
+		{"comma,inside", "a,b,c"},
+		{`double "quotes"`, `"`, `""`},
+		{`back\slash`, `\`, `\\`, `ends with \`},
+		{"{braces}", "{", "}", "{}", "{a,b}"},
+		{"NULL", "null", "Null", "NULL "},
+		{"tab\tnew\nline\rcarriage\vvertical\fform"},
+		{"æøå", "Zürich", "東京", "🍔", "emoji 🍟 with space"},
+		{`"{\,}"`, `\"`, `,"`},
+	}
+	for _, array := range arrays {
+		roundTrip(t, array)
+	}
+}
+
+func FuzzStringArrayRoundTrip(f *testing.F) {
+	f.Add("plain", `"quoted"`, "")
+	f.Add(`\`, "{,}", "NULL")
+	f.Add(" ", "æ ø", "🍔")
+	f.Fuzz(func(t *testing.T, a string, b string, c string) {
+		roundTrip(t, StringArray{a, b, c})
+	})
+}
+
+func TestStringArrayScan(t *testing.T) {
+	tests := []struct {
+		literal string
+		want    StringArray
+	}{
+		{"{}", StringArray{}},
+		{" { } ", StringArray{}},
+		{"{a,b}", StringArray{"a", "b"}},
+		{"{ a , b }", StringArray{"a", "b"}},
+		{`{"a b","c,d"}`, StringArray{"a b", "c,d"}},
+		{`{"a\"b","c\\d"}`, StringArray{`a"b`, `c\d`}},
+		{`{a\,b,c\ }`, StringArray{"a,b", "c "}},
+		{`{"NULL",\NULL}`, StringArray{"NULL", "NULL"}},
+		{`{""}`, StringArray{""}},
+	}
+	for _, test := range tests {
+		var got StringArray
+		if err := got.Scan(test.literal); err != nil {
+			t.Errorf("Scan(%q) failed: %v", test.literal, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Scan(%q) = %q, want %q", test.literal, got, test.want)
+		}
+	}
+}
+
+func TestStringArrayScanRejects(t *testing.T) {
+	for _, src := range []interface{}{
+		"",
+		"a,b",
+		"{a,b",
+		"{NULL}",
+		"{a,NULL}",
+		"{{a},{b}}",
+		"{a,,b}",
+		`{"a}`,
+		`{a"b}`,
+		`{a\}`,
+		`{"a"b}`,
+		42,
+	} {
+		var a StringArray
+		if err := a.Scan(src); err == nil {
+			t.Errorf("Scan(%q) = %q, want an error", src, a)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+// Package scim implements the Users resource of SCIM 2.0 (RFC 7643, RFC 7644), so a directory
+// like Azure AD can provision users before their first login and deactivate leavers.
+package scim
+
+import (
+	"encoding/json"
+	"errors"
+	"graphql-go/auth"
+	"graphql-go/graph/model"
+	"graphql-go/persistence"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"gorm.io/gorm"
+)
+
+const (
+	userSchema     = "urn:ietf:params:scim:schemas:core:2.0:User"
+	listSchema     = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	patchOpSchema  = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	errorSchema    = "urn:ietf:params:scim:api:messages:2.0:Error"
+	contentType    = "application/scim+json"
+	maxResultCount = 100
+)
+
+// Handler serves /Users, to be mounted at /scim/v2. The directory authenticates with an API
+// token of an admin, typically a service account: create one with createServiceAccount,
+// grantRole ADMIN and createApiToken with the READ and WRITE scopes.
+func Handler(db *gorm.DB) http.Handler {
+	router := chi.NewRouter()
+	router.Use(authenticate(db))
+
+	users := &usersHandler{db: db}
+	router.Get("/Users", users.list)
+	router.Post("/Users", users.create)
+	router.Get("/Users/{id}", users.get)
+	router.Put("/Users/{id}", users.replace)
+	router.Patch("/Users/{id}", users.patch)
+	router.Delete("/Users/{id}", users.delete)
+	return router
+}
+
+// authenticate only lets admins through, limited to the scopes of their token
+func authenticate(db *gorm.DB) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, err := auth.Authenticate(r.Context(), db, r.Header.Get("Authorization"))
+			if err != nil {
+				writeError(w, http.StatusUnauthorized, "", err.Error())
+				return
+			}
+			if !auth.HasRole(auth.ForContext(ctx), model.RoleAdmin) {
+				writeError(w, http.StatusForbidden, "", "permission denied: provisioning requires the ADMIN role")
+				return
+			}
+
+			scope := model.TokenScopeWrite
+			if r.Method == http.MethodGet {
+				scope = model.TokenScopeRead
+			}
+			if !auth.HasScope(ctx, scope) {
+				writeError(w, http.StatusForbidden, "", "permission denied: token is missing the "+string(scope)+" scope")
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// scimError is the error response of RFC 7644 section 3.12
+type scimError struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+}
+
+// errBadRequest is returned for malformed requests, scimType is one of RFC 7644 table 9
+type errBadRequest struct {
+	scimType string
+	detail   string
+}
+
+func (e *errBadRequest) Error() string {
+	return e.detail
+}
+
+func writeError(w http.ResponseWriter, status int, scimType string, detail string) {
+	writeJSON(w, status, scimError{
+		Schemas:  []string{errorSchema},
+		Status:   strconv.Itoa(status),
+		ScimType: scimType,
+		Detail:   detail,
+	})
+}
+
+// writeStoreError maps errors of the handlers to SCIM error responses
+func writeStoreError(w http.ResponseWriter, err error) {
+	var badRequest *errBadRequest
+	switch {
+	case errors.As(err, &badRequest):
+		status := http.StatusBadRequest
+		if badRequest.scimType == "uniqueness" {
+			status = http.StatusConflict
+		}
+		writeError(w, status, badRequest.scimType, badRequest.detail)
+	case errors.Is(err, gorm.ErrRecordNotFound), errors.Is(err, persistence.ErrNotFound):
+		writeError(w, http.StatusNotFound, "", "user not found")
+	case errors.Is(err, persistence.ErrConflict):
+		writeError(w, http.StatusConflict, "", "user was changed in the meantime, retry")
+	default:
+		writeError(w, http.StatusInternalServerError, "", err.Error())
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
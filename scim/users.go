@@ -0,0 +1,499 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"graphql-go/graph/model"
+	"graphql-go/persistence"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// userResource is the SCIM representation of a user. The userName is the email address,
+// which is also what logins are linked by until the user logs in the first time.
+type userResource struct {
+	Schemas      []string     `json:"schemas"`
+	ID           string       `json:"id,omitempty"`
+	ExternalID   string       `json:"externalId,omitempty"`
+	UserName     string       `json:"userName"`
+	DisplayName  string       `json:"displayName,omitempty"`
+	Name         *userName    `json:"name,omitempty"`
+	Emails       []multiValue `json:"emails,omitempty"`
+	PhoneNumbers []multiValue `json:"phoneNumbers,omitempty"`
+	Active       *bool        `json:"active,omitempty"`
+	Meta         *meta        `json:"meta,omitempty"`
+}
+
+type userName struct {
+	Formatted  string `json:"formatted,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+type multiValue struct {
+	Value   string `json:"value"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+type meta struct {
+	ResourceType string `json:"resourceType"`
+	Location     string `json:"location"`
+}
+
+type listResponse struct {
+	Schemas      []string        `json:"schemas"`
+	TotalResults int64           `json:"totalResults"`
+	StartIndex   int             `json:"startIndex"`
+	ItemsPerPage int             `json:"itemsPerPage"`
+	Resources    []*userResource `json:"Resources"`
+}
+
+type patchRequest struct {
+	Schemas    []string         `json:"schemas"`
+	Operations []patchOperation `json:"Operations"`
+}
+
+type patchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value"`
+}
+
+func toResource(user *persistence.User) *userResource {
+	active := user.DeactivatedAt == nil
+	resource := &userResource{
+		Schemas:     []string{userSchema},
+		ID:          user.ID,
+		UserName:    user.Email,
+		DisplayName: user.Name,
+		Name:        &userName{Formatted: user.Name},
+		Emails:      []multiValue{{Value: user.Email, Type: "work", Primary: true}},
+		Active:      &active,
+		Meta: &meta{
+			ResourceType: "User",
+			Location:     "/scim/v2/Users/" + user.ID,
+		},
+	}
+	if user.ExternalId != nil {
+		resource.ExternalID = *user.ExternalId
+	}
+	if user.PhoneNumber != "" {
+		resource.PhoneNumbers = []multiValue{{Value: user.PhoneNumber, Type: "mobile"}}
+	}
+	return resource
+}
+
+type usersHandler struct {
+	db *gorm.DB
+}
+
+// users are all users except service accounts, which are not managed by the directory
+func users(db *gorm.DB) *gorm.DB {
+	return db.Model(&persistence.User{}).Where("service_account = ?", false)
+}
+
+func findUser(db *gorm.DB, id string) (*persistence.User, error) {
+	user := &persistence.User{}
+	if err := users(db).Where("id = ?", id).First(user).Error; err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// filterPattern matches the only kind of filter directories send to look up a user,
+// e.g. userName eq "jane@example.com"
+var filterPattern = regexp.MustCompile(`(?i)^\s*(\w+)\s+eq\s+"((?:[^"\\]|\\.)*)"\s*$`)
+
+func (h *usersHandler) list(w http.ResponseWriter, r *http.Request) {
+	query := users(h.db.WithContext(r.Context()))
+
+	if filter := r.URL.Query().Get("filter"); filter != "" {
+		match := filterPattern.FindStringSubmatch(filter)
+		if match == nil {
+			writeError(w, http.StatusBadRequest, "invalidFilter", "only filters of the form `attribute eq \"value\"` are supported")
+			return
+		}
+		value := strings.ReplaceAll(strings.ReplaceAll(match[2], `\"`, `"`), `\\`, `\`)
+		switch strings.ToLower(match[1]) {
+		case "username":
+			query = query.Where("LOWER(email) = LOWER(?)", value)
+		case "externalid":
+			query = query.Where("external_id = ?", value)
+		case "id":
+			query = query.Where("id = ?", value)
+		default:
+			writeError(w, http.StatusBadRequest, "invalidFilter", "cannot filter on "+match[1])
+			return
+		}
+	}
+
+	startIndex, err := strconv.Atoi(r.URL.Query().Get("startIndex"))
+	if err != nil || startIndex < 1 {
+		startIndex = 1
+	}
+	count, err := strconv.Atoi(r.URL.Query().Get("count"))
+	if err != nil || count < 0 || count > maxResultCount {
+		count = maxResultCount
+	}
+
+	// The query is used twice, for the count and the page
+	query = query.Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		writeStoreError(w, err)
+		return
+	}
+
+	var found []*persistence.User
+	if err := query.Order("id").Offset(startIndex - 1).Limit(count).Find(&found).Error; err != nil {
+		writeStoreError(w, err)
+		return
+	}
+
+	resources := make([]*userResource, len(found))
+	for i, user := range found {
+		resources[i] = toResource(user)
+	}
+	writeJSON(w, http.StatusOK, listResponse{
+		Schemas:      []string{listSchema},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	})
+}
+
+func (h *usersHandler) get(w http.ResponseWriter, r *http.Request) {
+	user, err := findUser(h.db.WithContext(r.Context()), chi.URLParam(r, "id"))
+	if err != nil {
+		writeStoreError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toResource(user))
+}
+
+func (h *usersHandler) create(w http.ResponseWriter, r *http.Request) {
+	var resource userResource
+	if err := json.NewDecoder(r.Body).Decode(&resource); err != nil {
+		writeError(w, http.StatusBadRequest, "invalidSyntax", err.Error())
+		return
+	}
+
+	user := &persistence.User{
+		ID:          uuid.New().String(),
+		Role:        model.RoleMember,
+		PendingLink: true,
+	}
+	if err := applyResource(user, &resource); err != nil {
+		writeStoreError(w, err)
+		return
+	}
+
+	err := h.db.WithContext(r.Context()).Transaction(func(tx *gorm.DB) error {
+		if err := checkUnique(tx, user); err != nil {
+			return err
+		}
+		return tx.Create(user).Error
+	})
+	if err != nil {
+		writeStoreError(w, err)
+		return
+	}
+
+	w.Header().Set("Location", "/scim/v2/Users/"+user.ID)
+	writeJSON(w, http.StatusCreated, toResource(user))
+}
+
+func (h *usersHandler) replace(w http.ResponseWriter, r *http.Request) {
+	var resource userResource
+	if err := json.NewDecoder(r.Body).Decode(&resource); err != nil {
+		writeError(w, http.StatusBadRequest, "invalidSyntax", err.Error())
+		return
+	}
+
+	user, err := h.update(r.Context(), chi.URLParam(r, "id"), func(user *persistence.User) error {
+		return applyResource(user, &resource)
+	})
+	if err != nil {
+		writeStoreError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toResource(user))
+}
+
+func (h *usersHandler) patch(w http.ResponseWriter, r *http.Request) {
+	var request patchRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeError(w, http.StatusBadRequest, "invalidSyntax", err.Error())
+		return
+	}
+
+	user, err := h.update(r.Context(), chi.URLParam(r, "id"), func(user *persistence.User) error {
+		for _, op := range request.Operations {
+			if err := applyPatch(user, op); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		writeStoreError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toResource(user))
+}
+
+// delete deactivates the user rather than deleting it, so the orders of leavers are kept
+func (h *usersHandler) delete(w http.ResponseWriter, r *http.Request) {
+	_, err := h.update(r.Context(), chi.URLParam(r, "id"), func(user *persistence.User) error {
+		setActive(user, false)
+		return nil
+	})
+	if err != nil {
+		writeStoreError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// update loads the user, applies the change and saves it. Sessions of users that are
+// deactivated by the change are revoked.
+func (h *usersHandler) update(ctx context.Context, id string, change func(user *persistence.User) error) (*persistence.User, error) {
+	var user *persistence.User
+	err := h.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		user, err = findUser(tx, id)
+		if err != nil {
+			return err
+		}
+		wasActive := user.DeactivatedAt == nil
+
+		if err := change(user); err != nil {
+			return err
+		}
+		if err := checkUnique(tx, user); err != nil {
+			return err
+		}
+		if err := persistence.SaveUser(tx, user); err != nil {
+			return err
+		}
+
+		if wasActive && user.DeactivatedAt != nil {
+			_, err = persistence.NewGormRepositories(tx).Sessions.RevokeByUser(ctx, user.ID)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// checkUnique rejects users whose email or external id is already taken by another user
+func checkUnique(tx *gorm.DB, user *persistence.User) error {
+	var count int64
+	if err := tx.Model(&persistence.User{}).
+		Where("id <> ? AND LOWER(email) = LOWER(?)", user.ID, user.Email).
+		Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return &errBadRequest{"uniqueness", "a user with userName " + user.Email + " already exists"}
+	}
+
+	if user.ExternalId != nil {
+		if err := tx.Model(&persistence.User{}).
+			Where("id <> ? AND external_id = ?", user.ID, *user.ExternalId).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return &errBadRequest{"uniqueness", "a user with externalId " + *user.ExternalId + " already exists"}
+		}
+	}
+	return nil
+}
+
+// applyResource sets the user's attributes from a full resource, as sent on create and replace
+func applyResource(user *persistence.User, resource *userResource) error {
+	if strings.TrimSpace(resource.UserName) == "" {
+		return &errBadRequest{"invalidValue", "userName is required"}
+	}
+
+	user.Email = resource.UserName
+	if email := primaryValue(resource.Emails, "work"); email != "" {
+		user.Email = email
+	}
+
+	switch {
+	case resource.DisplayName != "":
+		user.Name = resource.DisplayName
+	case resource.Name != nil && resource.Name.Formatted != "":
+		user.Name = resource.Name.Formatted
+	case resource.Name != nil && (resource.Name.GivenName != "" || resource.Name.FamilyName != ""):
+		user.Name = strings.TrimSpace(resource.Name.GivenName + " " + resource.Name.FamilyName)
+	case user.Name == "":
+		user.Name = resource.UserName
+	}
+
+	user.PhoneNumber = primaryValue(resource.PhoneNumbers, "mobile")
+	user.ExternalId = nil
+	if resource.ExternalID != "" {
+		user.ExternalId = &resource.ExternalID
+	}
+	if resource.Active != nil {
+		setActive(user, *resource.Active)
+	}
+	return nil
+}
+
+// primaryValue picks the primary value of a multi-valued attribute, else the one of the
+// preferred type, else the first
+func primaryValue(values []multiValue, preferredType string) string {
+	for _, v := range values {
+		if v.Primary {
+			return v.Value
+		}
+	}
+	for _, v := range values {
+		if strings.EqualFold(v.Type, preferredType) {
+			return v.Value
+		}
+	}
+	if len(values) > 0 {
+		return values[0].Value
+	}
+	return ""
+}
+
+func setActive(user *persistence.User, active bool) {
+	if active {
+		user.DeactivatedAt = nil
+	} else if user.DeactivatedAt == nil {
+		now := time.Now()
+		user.DeactivatedAt = &now
+	}
+}
+
+// applyPatch applies one PATCH operation. Attributes we do not store, like title or
+// department, are ignored rather than rejected, since directories send whatever is mapped.
+func applyPatch(user *persistence.User, op patchOperation) error {
+	operation := strings.ToLower(op.Op)
+	if operation != "add" && operation != "replace" && operation != "remove" {
+		return &errBadRequest{"invalidSyntax", "unsupported op " + op.Op}
+	}
+
+	// Without a path the value is an object of attributes to set
+	if op.Path == "" {
+		if operation == "remove" {
+			return &errBadRequest{"noTarget", "remove requires a path"}
+		}
+		var attributes map[string]json.RawMessage
+		if err := json.Unmarshal(op.Value, &attributes); err != nil {
+			return &errBadRequest{"invalidValue", "value must be an object when no path is given"}
+		}
+		for path, value := range attributes {
+			if err := applyPatch(user, patchOperation{Op: op.Op, Path: path, Value: value}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if operation == "remove" {
+		switch strings.ToLower(op.Path) {
+		case "externalid":
+			user.ExternalId = nil
+		case "phonenumbers", `phonenumbers[type eq "mobile"].value`, `phonenumbers[type eq "mobile"]`:
+			user.PhoneNumber = ""
+		case "username", "emails", "displayname", "active":
+			return &errBadRequest{"mutability", op.Path + " cannot be removed"}
+		}
+		return nil
+	}
+
+	switch strings.ToLower(op.Path) {
+	case "active":
+		active, err := parseBool(op.Value)
+		if err != nil {
+			return err
+		}
+		setActive(user, active)
+	case "username", `emails[type eq "work"].value`:
+		email, err := parseString(op.Value)
+		if err != nil {
+			return err
+		}
+		user.Email = email
+	case "emails":
+		var emails []multiValue
+		if err := json.Unmarshal(op.Value, &emails); err != nil {
+			return &errBadRequest{"invalidValue", "emails must be a list"}
+		}
+		if email := primaryValue(emails, "work"); email != "" {
+			user.Email = email
+		}
+	case "displayname", "name.formatted":
+		name, err := parseString(op.Value)
+		if err != nil {
+			return err
+		}
+		user.Name = name
+	case "externalid":
+		externalID, err := parseString(op.Value)
+		if err != nil {
+			return err
+		}
+		user.ExternalId = &externalID
+	case `phonenumbers[type eq "mobile"].value`:
+		phone, err := parseString(op.Value)
+		if err != nil {
+			return err
+		}
+		user.PhoneNumber = phone
+	case "phonenumbers":
+		var phones []multiValue
+		if err := json.Unmarshal(op.Value, &phones); err != nil {
+			return &errBadRequest{"invalidValue", "phoneNumbers must be a list"}
+		}
+		user.PhoneNumber = primaryValue(phones, "mobile")
+	}
+	return nil
+}
+
+func parseString(value json.RawMessage) (string, error) {
+	var s string
+	if err := json.Unmarshal(value, &s); err != nil || strings.TrimSpace(s) == "" {
+		return "", &errBadRequest{"invalidValue", "expected a non-empty string"}
+	}
+	return s, nil
+}
+
+// parseBool accepts booleans and their string forms, since Azure AD sends "False"
+func parseBool(value json.RawMessage) (bool, error) {
+	var b bool
+	if err := json.Unmarshal(value, &b); err == nil {
+		return b, nil
+	}
+	var s string
+	if err := json.Unmarshal(value, &s); err == nil {
+		if b, err := strconv.ParseBool(strings.ToLower(s)); err == nil {
+			return b, nil
+		}
+	}
+	return false, &errBadRequest{"invalidValue", "expected a boolean"}
+}
@@ -0,0 +1,382 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"graphql-go/auth"
+	"graphql-go/graph"
+	"graphql-go/graph/model"
+	"graphql-go/persistence"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"gorm.io/gorm"
+)
+
+// newTestDB returns a migrated in-memory SQLite database with signing keys configured
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	t.Setenv("DB_URL", "sqlite::memory:")
+	db, err := persistence.ConnectGORM(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+
+	if err := persistence.EnsureMigrated(db, true); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	if err := auth.ConfigureSigningKeys(ctx, db); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+// testServer serves the SCIM endpoint next to the GraphQL API, the way server.go mounts them
+type testServer struct {
+	*httptest.Server
+	db *gorm.DB
+	// token is an API token of the directory's admin service account
+	token string
+}
+
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+
+	db := newTestDB(t)
+	repos := persistence.NewGormRepositories(db)
+
+	srv := handler.New(graph.NewExecutableSchema(graph.Config{
+		Resolvers:  graph.NewResolver(repos),
+		Directives: graph.NewDirectives(repos),
+	}))
+	srv.AddTransport(transport.POST{})
+
+	mux := http.NewServeMux()
+	mux.Handle("/scim/v2/", http.StripPrefix("/scim/v2", Handler(db)))
+	mux.Handle("/query", auth.Middleware(db)(srv))
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	directory := &persistence.User{ID: "directory", Name: "Directory", Role: model.RoleAdmin, ServiceAccount: true}
+	if err := db.Create(directory).Error; err != nil {
+		t.Fatal(err)
+	}
+	token := createToken(t, db, directory, model.TokenScopeRead, model.TokenScopeWrite)
+	return &testServer{Server: server, db: db, token: token}
+}
+
+func createToken(t *testing.T, db *gorm.DB, user *persistence.User, scopes ...model.TokenScope) string {
+	t.Helper()
+
+	_, token, err := auth.CreateAPIToken(context.Background(), persistence.NewGormRepositories(db).APITokens, user, "scim", scopes, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+// do sends a SCIM request with the token and decodes the JSON response into out, if given
+func (s *testServer) do(t *testing.T, method string, path string, token string, body string, out interface{}) int {
+	t.Helper()
+
+	req, err := http.NewRequest(method, s.URL+"/scim/v2"+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := s.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			t.Fatalf("%s %s: decoding response with status %d: %v", method, path, resp.StatusCode, err)
+		}
+	}
+	return resp.StatusCode
+}
+
+// queryUsers lists the ids of Query.users as the holder of the access token
+func (s *testServer) queryUsers(t *testing.T, accessToken string) (int, []string) {
+	t.Helper()
+
+	req, _ := http.NewRequest(http.MethodPost, s.URL+"/query", strings.NewReader(`{"query":"{ users { id } }"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	resp, err := s.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return resp.StatusCode, nil
+	}
+
+	var body struct {
+		Data struct {
+			Users []struct{ ID string }
+		}
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	ids := make([]string, len(body.Data.Users))
+	for i, user := range body.Data.Users {
+		ids[i] = user.ID
+	}
+	return resp.StatusCode, ids
+}
+
+const jane = `{
+	"schemas": ["urn:ietf:params:scim:schemas:core:2.0:User"],
+	"externalId": "00u1jane",
+	"userName": "jane@example.com",
+	"name": {"givenName": "Jane", "familyName": "Doe"},
+	"phoneNumbers": [{"value": "+45 12345678", "type": "mobile"}],
+	"active": true
+}`
+
+func (s *testServer) createJane(t *testing.T) *userResource {
+	t.Helper()
+
+	var created userResource
+	if status := s.do(t, http.MethodPost, "/Users", s.token, jane, &created); status != http.StatusCreated {
+		t.Fatalf("creating Jane: got status %d", status)
+	}
+	return &created
+}
+
+func TestCreateUser(t *testing.T) {
+	s := newTestServer(t)
+
+	created := s.createJane(t)
+	if created.ID == "" || created.UserName != "jane@example.com" || created.DisplayName != "Jane Doe" ||
+		created.ExternalID != "00u1jane" || created.Active == nil || !*created.Active {
+		t.Errorf("created %+v", created)
+	}
+
+	var stored persistence.User
+	if err := s.db.First(&stored, "id = ?", created.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if stored.Role != model.RoleMember || !stored.PendingLink || stored.PhoneNumber != "+45 12345678" {
+		t.Errorf("stored %+v, want a member pending a login link", stored)
+	}
+
+	var fetched userResource
+	if status := s.do(t, http.MethodGet, "/Users/"+created.ID, s.token, "", &fetched); status != http.StatusOK || fetched.ID != created.ID {
+		t.Errorf("fetching Jane: got status %d and %+v", status, fetched)
+	}
+	if status := s.do(t, http.MethodGet, "/Users/missing", s.token, "", nil); status != http.StatusNotFound {
+		t.Errorf("fetching an unknown user: got status %d, want 404", status)
+	}
+}
+
+func TestCreateUserDuplicate(t *testing.T) {
+	s := newTestServer(t)
+	s.createJane(t)
+
+	duplicates := []string{
+		strings.Replace(jane, `"jane@example.com"`, `"JANE@example.com"`, 1),
+		strings.Replace(jane, `"jane@example.com"`, `"jane.doe@example.com"`, 1),
+	}
+	for _, duplicate := range duplicates {
+		var resp scimError
+		status := s.do(t, http.MethodPost, "/Users", s.token, duplicate, &resp)
+		if status != http.StatusConflict || resp.ScimType != "uniqueness" || resp.Status != "409" {
+			t.Errorf("creating a duplicate: got status %d and %+v, want 409 uniqueness", status, resp)
+		}
+	}
+
+	var count int64
+	if err := s.db.Model(&persistence.User{}).Where("service_account = ?", false).Count(&count).Error; err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Errorf("got %d users, want only Jane", count)
+	}
+}
+
+func TestListUsersFilter(t *testing.T) {
+	s := newTestServer(t)
+	created := s.createJane(t)
+	bob := strings.NewReplacer(`"jane@example.com"`, `"bob@example.com"`, `"00u1jane"`, `"00u2bob"`).Replace(jane)
+	if status := s.do(t, http.MethodPost, "/Users", s.token, bob, nil); status != http.StatusCreated {
+		t.Fatalf("creating Bob: got status %d", status)
+	}
+
+	tests := []struct {
+		filter string
+		ids    []string
+	}{
+		{`userName eq "JANE@example.com"`, []string{created.ID}},
+		{`externalId eq "00u1jane"`, []string{created.ID}},
+		{`userName eq "nobody@example.com"`, nil},
+		// Service accounts are not the directory's to manage
+		{`id eq "directory"`, nil},
+	}
+	for _, test := range tests {
+		var resp listResponse
+		status := s.do(t, http.MethodGet, "/Users?filter="+url.QueryEscape(test.filter), s.token, "", &resp)
+		if status != http.StatusOK {
+			t.Errorf("%s: got status %d", test.filter, status)
+			continue
+		}
+		if resp.TotalResults != int64(len(test.ids)) || len(resp.Resources) != len(test.ids) {
+			t.Errorf("%s: got %d of %d results, want %d", test.filter, len(resp.Resources), resp.TotalResults, len(test.ids))
+			continue
+		}
+		for i, id := range test.ids {
+			if resp.Resources[i].ID != id {
+				t.Errorf("%s: got %s, want %s", test.filter, resp.Resources[i].ID, id)
+			}
+		}
+	}
+
+	for _, filter := range []string{`userName co "jane"`, `title eq "Chef"`, `userName eq jane`} {
+		var resp scimError
+		if status := s.do(t, http.MethodGet, "/Users?filter="+url.QueryEscape(filter), s.token, "", &resp); status != http.StatusBadRequest || resp.ScimType != "invalidFilter" {
+			t.Errorf("%s: got status %d and %+v, want 400 invalidFilter", filter, status, resp)
+		}
+	}
+}
+
+func TestDeactivateUser(t *testing.T) {
+	s := newTestServer(t)
+	created := s.createJane(t)
+
+	user := &persistence.User{}
+	if err := s.db.First(user, "id = ?", created.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	tokens, err := auth.IssueTokens(s.db, user)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status, ids := s.queryUsers(t, tokens.AccessToken); status != http.StatusOK || len(ids) != 2 {
+		t.Fatalf("Jane listing users: got status %d and %v, want Jane and the service account", status, ids)
+	}
+
+	// Azure AD sends the boolean as a string
+	const deactivate = `{
+		"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+		"Operations": [{"op": "Replace", "path": "active", "value": "False"}]
+	}`
+	var patched userResource
+	if status := s.do(t, http.MethodPatch, "/Users/"+created.ID, s.token, deactivate, &patched); status != http.StatusOK {
+		t.Fatalf("deactivating Jane: got status %d", status)
+	}
+	if patched.Active == nil || *patched.Active {
+		t.Errorf("got active %v after deactivating", patched.Active)
+	}
+
+	var sessions []persistence.Session
+	if err := s.db.Find(&sessions, "user_id = ?", created.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(sessions) != 1 || sessions[0].RevokedAt == nil {
+		t.Errorf("got sessions %+v, want the session revoked", sessions)
+	}
+	if _, err := auth.RefreshTokens(s.db, tokens.RefreshToken); err == nil {
+		t.Error("refreshed the tokens of a deactivated user")
+	}
+	if status, _ := s.queryUsers(t, tokens.AccessToken); status != http.StatusUnauthorized {
+		t.Errorf("deactivated user querying: got status %d, want 401", status)
+	}
+
+	// Deactivated users are still served over SCIM, but no longer listed in the API
+	other := &persistence.User{ID: "other", Name: "Other", Email: "other@example.com", Role: model.RoleMember}
+	if err := s.db.Create(other).Error; err != nil {
+		t.Fatal(err)
+	}
+	otherTokens, err := auth.IssueTokens(s.db, other)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, ids := s.queryUsers(t, otherTokens.AccessToken)
+	for _, id := range ids {
+		if id == created.ID {
+			t.Error("Query.users lists the deactivated user")
+		}
+	}
+	var fetched userResource
+	if status := s.do(t, http.MethodGet, "/Users/"+created.ID, s.token, "", &fetched); status != http.StatusOK || *fetched.Active {
+		t.Errorf("fetching the deactivated user: got status %d and active %v", status, fetched.Active)
+	}
+
+	// Reactivating lets the user log in again, the revoked session stays revoked
+	const reactivate = `{"Operations": [{"op": "replace", "value": {"active": true}}]}`
+	if status := s.do(t, http.MethodPatch, "/Users/"+created.ID, s.token, reactivate, &patched); status != http.StatusOK || !*patched.Active {
+		t.Errorf("reactivating Jane: got status %d and active %v", status, patched.Active)
+	}
+	if status, _ := s.queryUsers(t, tokens.AccessToken); status != http.StatusUnauthorized {
+		t.Errorf("revoked session after reactivating: got status %d, want 401", status)
+	}
+}
+
+func TestDeleteUser(t *testing.T) {
+	s := newTestServer(t)
+	created := s.createJane(t)
+
+	if status := s.do(t, http.MethodDelete, "/Users/"+created.ID, s.token, "", nil); status != http.StatusNoContent {
+		t.Fatalf("deleting Jane: got status %d", status)
+	}
+	var stored persistence.User
+	if err := s.db.First(&stored, "id = ?", created.ID).Error; err != nil {
+		t.Fatalf("deleting removed the user: %v", err)
+	}
+	if stored.DeactivatedAt == nil {
+		t.Error("deleted user is still active")
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	s := newTestServer(t)
+
+	member := &persistence.User{ID: "member", Name: "Member", Email: "member@example.com", Role: model.RoleMember}
+	if err := s.db.Create(member).Error; err != nil {
+		t.Fatal(err)
+	}
+	readOnly := &persistence.User{ID: "reader", Name: "Reader", Role: model.RoleAdmin, ServiceAccount: true}
+	if err := s.db.Create(readOnly).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		token  string
+		status int
+	}{
+		{"no token", http.MethodGet, "", http.StatusUnauthorized},
+		{"made up token", http.MethodGet, "bgr_made-up", http.StatusUnauthorized},
+		{"member", http.MethodGet, createToken(t, s.db, member, model.TokenScopeRead, model.TokenScopeWrite), http.StatusForbidden},
+		{"read only token writing", http.MethodPost, createToken(t, s.db, readOnly, model.TokenScopeRead), http.StatusForbidden},
+		{"read only token reading", http.MethodGet, createToken(t, s.db, readOnly, model.TokenScopeRead), http.StatusOK},
+	}
+	for _, test := range tests {
+		if status := s.do(t, test.method, "/Users", test.token, jane, nil); status != test.status {
+			t.Errorf("%s: got status %d, want %d", test.name, status, test.status)
+		}
+	}
+}
@@ -1,10 +1,16 @@
 package main
 
 import (
+	"context"
 	"graphql-go/auth"
+	"graphql-go/auth/mockidp"
+	"graphql-go/core/office"
 	"graphql-go/graph"
+	"graphql-go/mailer"
 	"graphql-go/persistence"
+	"graphql-go/scim"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"time"
@@ -17,12 +23,21 @@ import (
 	"github.com/gorilla/websocket"
 )
 
-const defaultPort = "8080"
+const (
+	defaultPort           = "8080"
+	defaultRequestTimeout = 30 * time.Second
+)
 
 func corsMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Set CORS headers
-		w.Header().Set("Access-Control-Allow-Origin", "*")
+		// Set CORS headers, our own frontends may also send the session cookie
+		if origin := r.Header.Get("Origin"); auth.AllowedOrigin(origin) {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Set("Access-Control-Allow-Credentials", "true")
+			w.Header().Add("Vary", "Origin")
+		} else {
+			w.Header().Set("Access-Control-Allow-Origin", "*")
+		}
 		w.Header().Set("Access-Control-Allow-Methods", "POST, GET, OPTIONS")
 		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
 
@@ -37,23 +52,144 @@ func corsMiddleware(next http.Handler) http.Handler {
 	})
 }
 
+// requestTimeout cancels the context of a GraphQL request once it passes, which aborts the
+// database statements the resolvers run with it. Subscriptions over websockets are left alone,
+// they last as long as the client stays.
+func requestTimeout(timeout time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if websocket.IsWebSocketUpgrade(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx, cancel := context.WithTimeout(r.Context(), timeout)
+			defer cancel()
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// startMockIdP serves the mock identity provider on its own address. It is listening when this
+// returns, so it can be configured like any OIDC provider, e.g. with OIDC_PROVIDERS=mock and
+// OIDC_MOCK_ISSUER=http://localhost:9000. Users are read from the JSON file in MOCK_IDP_USERS.
+func startMockIdP(addr string) error {
+	users := []mockidp.User{{Subject: "dev", Name: "Dev User", Email: "dev@example.com"}}
+	if path := os.Getenv("MOCK_IDP_USERS"); path != "" {
+		var err error
+		if users, err = mockidp.LoadUsers(path); err != nil {
+			return err
+		}
+	}
+
+	issuer := os.Getenv("MOCK_IDP_ISSUER")
+	if issuer == "" {
+		issuer = "http://localhost" + addr
+	}
+
+	idp, err := mockidp.New(mockidp.Config{
+		Issuer:   issuer,
+		ClientID: os.Getenv("MOCK_IDP_CLIENT_ID"),
+		Users:    users,
+	})
+	if err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("mock identity provider listening at %s", issuer)
+	go func() {
+		log.Fatal(http.Serve(listener, idp.Handler()))
+	}()
+	return nil
+}
+
 func main() {
 	port := os.Getenv("PORT")
 	if port == "" {
 		port = defaultPort
 	}
 
-	gorm := persistence.ConnectGORM()
+	// Burger days follow the calendar of the office, and migrations convert times on its clock
+	if err := office.Configure(); err != nil {
+		log.Fatal(err)
+	}
 
-	// Create a resolver with the database connection
-	resolver := graph.NewResolver(gorm)
+	gorm, err := persistence.ConnectGORM(context.Background())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(gorm, os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	// Refuse to run against an outdated schema, unless told to bring it up to date
+	if err := persistence.EnsureMigrated(gorm, os.Getenv("AUTO_MIGRATE") == "true"); err != nil {
+		log.Fatalf("failed to migrate database: %v", err)
+	}
+
+	// only use the mock identity provider on localhost, it logs anyone in as any user
+	if os.Getenv("DEBUG") == "true" && os.Getenv("MOCK_IDP_ADDR") != "" {
+		if err := startMockIdP(os.Getenv("MOCK_IDP_ADDR")); err != nil {
+			log.Fatalf("failed to start mock identity provider: %v", err)
+		}
+	}
+
+	if err := auth.ConfigureProviders(context.Background()); err != nil {
+		log.Fatalf("failed to configure identity providers: %v", err)
+	}
+	if err := auth.ConfigureSigningKeys(context.Background(), gorm); err != nil {
+		log.Fatalf("failed to configure signing keys: %v", err)
+	}
+
+	// Guests log in with emailed links when a mail server is configured, if their email is in
+	// one of the MAGIC_LINK_DOMAINS. Without a mail server, the links are written to the log on
+	// localhost
+	sender, err := mailer.FromEnv()
+	if err != nil {
+		log.Fatalf("failed to configure mail: %v", err)
+	}
+	publicURL := os.Getenv("PUBLIC_URL")
+	if os.Getenv("DEBUG") == "true" {
+		if sender == nil {
+			sender = mailer.LogSender{}
+		}
+		if publicURL == "" {
+			publicURL = "http://localhost:" + port
+		}
+	}
+	if sender != nil {
+		if err := auth.ConfigureMagicLinks(sender, publicURL); err != nil {
+			log.Fatalf("failed to configure login links: %v", err)
+		}
+	}
+
+	repos := persistence.NewGormRepositories(gorm)
+	if err := persistence.StartPurge(context.Background(), repos); err != nil {
+		log.Fatalf("failed to start purging deleted burger days: %v", err)
+	}
+
+	// Create a resolver with the repositories
+	resolver := graph.NewResolver(repos)
 
 	// Create your GraphQL server handler
-	srv := handler.New(graph.NewExecutableSchema(graph.Config{Resolvers: resolver}))
+	srv := handler.New(graph.NewExecutableSchema(graph.Config{
+		Resolvers:  resolver,
+		Directives: graph.NewDirectives(repos),
+	}))
 
 	// Add WebSocket transport support for subscriptions
 	srv.AddTransport(transport.Websocket{
 		KeepAlivePingInterval: 10 * time.Second,
+		InitFunc:              auth.WebsocketInitFunc(gorm),
 		Upgrader: websocket.Upgrader{
 			CheckOrigin: func(r *http.Request) bool {
 				// Allow all connections
@@ -67,6 +203,9 @@ func main() {
 	srv.AddTransport(transport.GET{})
 	srv.AddTransport(transport.POST{})
 	srv.Use(extension.Introspection{})
+	srv.SetErrorPresenter(graph.ErrorPresenter)
+	srv.AroundOperations(auth.EnforceScopes)
+	srv.AroundOperations(auth.LogImpersonation)
 
 	// Create a new Chi router
 	router := chi.NewRouter()
@@ -74,23 +213,37 @@ func main() {
 	// Use the Chi router methods to handle routes
 	router.Use(corsMiddleware)
 
+	timeout := defaultRequestTimeout
+	if value := os.Getenv("REQUEST_TIMEOUT"); value != "" {
+		if timeout, err = time.ParseDuration(value); err != nil || timeout <= 0 {
+			log.Fatalf("invalid REQUEST_TIMEOUT: %s", value)
+		}
+	}
+
 	gqlRouter := chi.NewRouter()
+	gqlRouter.Use(requestTimeout(timeout))
 	gqlRouter.Use(auth.Middleware(gorm))
 	gqlRouter.Handle("/", srv)
 
 	authRouter := chi.NewRouter()
 	authRouter.HandleFunc("/login", auth.HandleLogin)
-	authRouter.HandleFunc("/auth/callback", auth.HandleCallback)
-	// only use below on localhost
-	if os.Getenv("DEBUG") == "true" {
-		authRouter.HandleFunc("/login-dev", auth.HandleLoginDev)
+	authRouter.HandleFunc("/auth/callback", auth.HandleCallback(gorm))
+	authRouter.HandleFunc("/auth/token", auth.HandleTokenExchange(gorm))
+	authRouter.HandleFunc("/refresh", auth.HandleRefresh(gorm))
+	authRouter.HandleFunc("/logout", auth.HandleLogout(gorm))
+	authRouter.HandleFunc("/.well-known/jwks.json", auth.HandleJWKS)
+	if sender != nil {
+		authRouter.HandleFunc("/auth/magic-link", auth.HandleMagicLinkRequest(gorm))
+		authRouter.HandleFunc("/auth/magic-link/verify", auth.HandleMagicLinkVerify(gorm))
 	}
 
 	router.Mount("/", authRouter)
 	router.Mount("/query", gqlRouter)
+	router.Mount("/scim/v2", scim.Handler(gorm))
 
 	// Optionally, protect the GraphQL playground with the nonceMiddleware as well
 	// This means accessing the playground will also require a valid nonce
+	// Log in with /login?mode=cookie&redirect_url=/ to use the playground with a session cookie
 	playgroundHandler := playground.Handler("GraphQL playground", "/query")
 	router.Handle("/", playgroundHandler)
 